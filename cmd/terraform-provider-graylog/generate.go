@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog"
+	gen "github.com/sven-borkert/terraform-provider-graylog/graylog/generate"
+)
+
+const generateUsage = `Usage: terraform-provider-graylog generate [options]
+
+Generate Terraform configuration files with import blocks from an existing Graylog.
+One file is written per resource type.
+
+The connection is configured with the same environment variables as the provider:
+GRAYLOG_WEB_ENDPOINT_URI, GRAYLOG_AUTH_NAME, GRAYLOG_AUTH_PASSWORD,
+GRAYLOG_X_REQUESTED_BY and GRAYLOG_API_VERSION.
+
+Options:
+`
+
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	out := fs.String("out", ".", "directory where the files are written")
+	types := fs.String("types", "", "comma separated resource types to generate. all of "+
+		strings.Join(gen.ResourceTypes(), ", ")+" by default")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), generateUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	// the resources write debug logs, which are shown only if TF_LOG is set as well as Terraform
+	if os.Getenv("TF_LOG") == "" {
+		log.SetOutput(io.Discard)
+	}
+
+	ctx := context.Background()
+	p := graylog.Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		return fmt.Errorf("failed to configure the provider: %s", diags[0].Summary)
+	}
+
+	opts := gen.Options{OutputDir: *out}
+	if *types != "" {
+		opts.Types = strings.Split(*types, ",")
+	}
+	paths, err := gen.Run(ctx, p, opts)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	return nil
+}
@@ -1,11 +1,21 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := generate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: graylog.Provider,
 	})
@@ -60,7 +60,11 @@ resource "graylog_index_set" "tf_e2e" {
   rotation_strategy                   = jsonencode(local.rotation_strategy)
   retention_strategy_class            = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy"
   retention_strategy                  = jsonencode(local.retention_strategy)
-  data_tiering                        = jsonencode({ type = "hot_only", index_lifetime_min = "P30D", index_lifetime_max = "P40D" })
+  data_tiering {
+    type               = "hot_only"
+    index_lifetime_min = "P30D"
+    index_lifetime_max = "P40D"
+  }
   field_restrictions                  = jsonencode({})
   index_analyzer                      = "standard"
   index_set_template_id               = data.graylog_index_set_template.hot30.id
@@ -117,11 +121,11 @@ resource "graylog_index_set" "packetbeat" {
     type                  = "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig"
     max_number_of_indices = 5
   })
-  data_tiering                        = jsonencode({
+  data_tiering {
     type               = "hot_only"
     index_lifetime_min = "P7D"
     index_lifetime_max = "P8D"
-  })
+  }
   field_restrictions                  = jsonencode({})
   index_analyzer                      = "standard"
   index_set_template_id               = data.graylog_index_set_template.hot7.id
@@ -13,15 +13,18 @@ import (
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/sidecar"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/sidecar/collector"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/sidecar/configuration"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/sidecar/variable"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/stream"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/stream/alarmcallback"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/stream/alert/condition"
 	streamOutput "github.com/sven-borkert/terraform-provider-graylog/graylog/client/stream/output"
 	streamRule "github.com/sven-borkert/terraform-provider-graylog/graylog/client/stream/rule"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/search/saved"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/clusterconfig"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/grok"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/fieldtype"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/indexset"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/profile"
 	indextemplate "github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/template"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/input"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/input/extractor"
@@ -41,6 +44,7 @@ type Client struct {
 	APIVersion              string
 	AlarmCallback           alarmcallback.Client
 	AlertCondition          condition.Client
+	ClusterConfig           clusterconfig.Client
 	Collector               collector.Client
 	Dashboard               dashboard.Client
 	DashboardWidget         widget.Client
@@ -50,6 +54,7 @@ type Client struct {
 	Extractor               extractor.Client
 	FieldType               fieldtype.Client
 	Grok                    grok.Client
+	IndexFieldTypeProfile   profile.Client
 	IndexSet                indexset.Client
 	IndexSetTemplate        indextemplate.Client
 	Input                   input.Client
@@ -62,6 +67,7 @@ type Client struct {
 	Role                    role.Client
 	Sidecar                 sidecar.Client
 	SidecarConfiguration    configuration.Client
+	SidecarVariable         variable.Client
 	Stream                  stream.Client
 	StreamOutput            streamOutput.Client
 	StreamRule              streamRule.Client
@@ -94,6 +100,9 @@ func New(m interface{}) (Client, error) {
 		AlertCondition: condition.Client{
 			Client: httpClient,
 		},
+		ClusterConfig: clusterconfig.Client{
+			Client: httpClient,
+		},
 		Collector: collector.Client{
 			Client: httpClient,
 		},
@@ -121,6 +130,9 @@ func New(m interface{}) (Client, error) {
 		Grok: grok.Client{
 			Client: httpClient,
 		},
+		IndexFieldTypeProfile: profile.Client{
+			Client: httpClient,
+		},
 		IndexSet: indexset.Client{
 			Client: httpClient,
 		},
@@ -157,6 +169,9 @@ func New(m interface{}) (Client, error) {
 		SidecarConfiguration: configuration.Client{
 			Client: httpClient,
 		},
+		SidecarVariable: variable.Client{
+			Client: httpClient,
+		},
 		Stream: stream.Client{
 			Client: httpClient,
 		},
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/suzuki-shunsuke/go-httpclient/httpclient"
 
@@ -30,6 +32,38 @@ func (cl Client) Get(
 	return body, resp, err
 }
 
+type GetAllParams struct {
+	Page    int
+	PerPage int
+}
+
+func (params *GetAllParams) query() url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+	if params.Page != 0 {
+		query.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.PerPage != 0 {
+		query.Add("per_page", strconv.Itoa(params.PerPage))
+	}
+	return query
+}
+
+func (cl Client) Gets(
+	ctx context.Context, params *GetAllParams,
+) (map[string]interface{}, *http.Response, error) {
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/events/definitions",
+		Query:        params.query(),
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
 func (cl Client) Create(
 	ctx context.Context, data map[string]interface{},
 ) (map[string]interface{}, *http.Response, error) {
@@ -37,3 +37,24 @@ func (cl Client) GetAll(ctx context.Context) (map[string]interface{}, *http.Resp
 	})
 	return body, resp, err
 }
+
+// BulkAction requests the action to the collectors of the sidecars through the administration API.
+// The action is one of "start", "stop" and "restart".
+// collectors is the list of objects with sidecar_id and collector_ids.
+func (cl Client) BulkAction(
+	ctx context.Context, action string, collectors []interface{},
+) (*http.Response, error) {
+	if action == "" {
+		return nil, errors.New("action is required")
+	}
+
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method: "PUT",
+		Path:   "/sidecar/administration/action",
+		RequestBody: map[string]interface{}{
+			"action":     action,
+			"collectors": collectors,
+		},
+	})
+	return resp, err
+}
@@ -30,6 +30,16 @@ func (cl Client) Get(
 	return body, resp, err
 }
 
+func (cl Client) Gets(ctx context.Context) (map[string]interface{}, *http.Response, error) {
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/sidecar/collectors",
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
 func (cl Client) Create(
 	ctx context.Context, collector map[string]interface{},
 ) (map[string]interface{}, *http.Response, error) {
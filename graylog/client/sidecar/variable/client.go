@@ -0,0 +1,74 @@
+package variable
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/go-httpclient/httpclient"
+)
+
+type Client struct {
+	Client httpclient.Client
+}
+
+// Gets returns all configuration variables.
+// Graylog doesn't provide the API to get a configuration variable by ID.
+func (cl Client) Gets(ctx context.Context) ([]map[string]interface{}, *http.Response, error) {
+	body := []map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/sidecar/configuration_variables",
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
+func (cl Client) Create(
+	ctx context.Context, variable map[string]interface{},
+) (map[string]interface{}, *http.Response, error) {
+	if variable == nil {
+		return nil, nil, errors.New("request body is nil")
+	}
+
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "POST",
+		Path:         "/sidecar/configuration_variables",
+		RequestBody:  variable,
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
+func (cl Client) Update(
+	ctx context.Context, id string, variable map[string]interface{},
+) (map[string]interface{}, *http.Response, error) {
+	if id == "" {
+		return nil, nil, errors.New("id is required")
+	}
+	if variable == nil {
+		return nil, nil, errors.New("request body is nil")
+	}
+
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "PUT",
+		Path:         "/sidecar/configuration_variables/" + id,
+		RequestBody:  variable,
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
+func (cl Client) Delete(ctx context.Context, id string) (*http.Response, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method: "DELETE",
+		Path:   "/sidecar/configuration_variables/" + id,
+	})
+	return resp, err
+}
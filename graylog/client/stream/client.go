@@ -108,3 +108,45 @@ func (cl Client) Pause(ctx context.Context, id string) (*http.Response, error) {
 	})
 	return resp, err
 }
+
+// TestMatch tests a message against the rules of the stream.
+// The response has matches and rules, which maps the IDs of the rules to whether they match.
+func (cl Client) TestMatch(
+	ctx context.Context, id string, message map[string]interface{},
+) (map[string]interface{}, *http.Response, error) {
+	if id == "" {
+		return nil, nil, errors.New("id is required")
+	}
+
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "POST",
+		Path:         "/streams/" + id + "/testMatch",
+		RequestBody:  map[string]interface{}{"message": message},
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
+// Clone copies the stream with its rules and outputs.
+// data has title, index_set_id, description and remove_matches_from_default_stream.
+// The clone is paused.
+func (cl Client) Clone(
+	ctx context.Context, id string, data map[string]interface{},
+) (map[string]interface{}, *http.Response, error) {
+	if id == "" {
+		return nil, nil, errors.New("id is required")
+	}
+	if data == nil {
+		return nil, nil, errors.New("request body is nil")
+	}
+
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "POST",
+		Path:         "/streams/" + id + "/clone",
+		RequestBody:  data,
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
@@ -0,0 +1,68 @@
+package clusterconfig
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/go-httpclient/httpclient"
+)
+
+// Classes of the cluster configurations which are managed by the typed resources.
+const (
+	ClassMessageProcessors = "org.graylog2.messageprocessors.MessageProcessorsConfig"
+	ClassURLAllowlist      = "org.graylog2.system.urlallowlist.UrlAllowlist"
+)
+
+type Client struct {
+	Client httpclient.Client
+}
+
+// Get returns the cluster configuration of the class.
+// The returned configuration is empty if the configuration of the class isn't stored.
+func (cl Client) Get(ctx context.Context, class string) (map[string]interface{}, *http.Response, error) {
+	if class == "" {
+		return nil, nil, errors.New("class is required")
+	}
+
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/cluster_config/" + class,
+		ResponseBody: &body,
+	})
+	if resp != nil && resp.StatusCode == http.StatusNoContent {
+		return map[string]interface{}{}, resp, nil
+	}
+	return body, resp, err
+}
+
+// Update replaces the cluster configuration of the class.
+func (cl Client) Update(
+	ctx context.Context, class string, data map[string]interface{},
+) (*http.Response, error) {
+	if class == "" {
+		return nil, errors.New("class is required")
+	}
+	if data == nil {
+		return nil, errors.New("request body is nil")
+	}
+
+	return cl.Client.Call(ctx, httpclient.CallParams{
+		Method:      "PUT",
+		Path:        "/system/cluster_config/" + class,
+		RequestBody: data,
+	})
+}
+
+// Delete removes the cluster configuration of the class, so Graylog uses the default configuration.
+func (cl Client) Delete(ctx context.Context, class string) (*http.Response, error) {
+	if class == "" {
+		return nil, errors.New("class is required")
+	}
+
+	return cl.Client.Call(ctx, httpclient.CallParams{
+		Method: "DELETE",
+		Path:   "/system/cluster_config/" + class,
+	})
+}
@@ -5,10 +5,29 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/suzuki-shunsuke/go-httpclient/httpclient"
 )
 
+// Types are the field types which can be set to custom field type mappings.
+var Types = []string{
+	"string", "string_fts", "long", "double", "date",
+	"boolean", "binary", "ip", "geo-point",
+}
+
+// Origins of the field types of an index set.
+const (
+	// OriginIndex is the type of the index mapping.
+	OriginIndex = "INDEX"
+	// OriginOverriddenIndex is the type of a custom field type mapping of the index set.
+	OriginOverriddenIndex = "OVERRIDDEN_INDEX"
+	// OriginOverriddenProfile is the type of the field type profile of the index set.
+	OriginOverriddenProfile = "OVERRIDDEN_PROFILE"
+	// OriginProfile is the type of the field type profile which isn't applied to the index mapping yet.
+	OriginProfile = "PROFILE"
+)
+
 type Client struct {
 	Client httpclient.Client
 }
@@ -109,3 +128,43 @@ func (cl Client) GetFieldType(ctx context.Context, indexSetID, fieldName string)
 
 	return nil, resp, nil
 }
+
+type GetFieldTypesParams struct {
+	Page    int
+	PerPage int
+	Query   string
+}
+
+func (params *GetFieldTypesParams) query() url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+	if params.Page != 0 {
+		query.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.PerPage != 0 {
+		query.Add("per_page", strconv.Itoa(params.PerPage))
+	}
+	if params.Query != "" {
+		query.Add("query", params.Query)
+	}
+	return query
+}
+
+// GetFieldTypes returns a page of the field types of an index set with their origins.
+func (cl Client) GetFieldTypes(
+	ctx context.Context, indexSetID string, params *GetFieldTypesParams,
+) (map[string]interface{}, *http.Response, error) {
+	if indexSetID == "" {
+		return nil, nil, errors.New("index_set_id is required")
+	}
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/indices/index_sets/types/" + indexSetID,
+		Query:        params.query(),
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
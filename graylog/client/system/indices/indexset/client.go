@@ -162,3 +162,86 @@ func (cl Client) WaitForDeflector(ctx context.Context, id string, timeout time.D
 
 	return errors.New("timeout waiting for deflector to be ready")
 }
+
+// WaitForCycle polls the deflector status until it points to an index other than previousTarget or timeout is reached
+func (cl Client) WaitForCycle(
+	ctx context.Context, id, previousTarget string, timeout time.Duration, interval time.Duration,
+) (*DeflectorStatus, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		status, _, err := cl.GetDeflectorStatus(ctx, id)
+		if err == nil && status.IsUp && status.CurrentTarget != "" && status.CurrentTarget != previousTarget {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+			// continue polling
+		}
+	}
+
+	return nil, errors.New("timeout waiting for deflector to point to the new index")
+}
+
+// Cycle cycles the deflector of an index set, which rotates the active write index.
+func (cl Client) Cycle(ctx context.Context, id string) (*http.Response, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	return cl.Client.Call(ctx, httpclient.CallParams{
+		Method: "POST",
+		Path:   "/system/deflector/" + id + "/cycle",
+	})
+}
+
+// RebuildIndexRanges rebuilds the index ranges of an index set asynchronously.
+func (cl Client) RebuildIndexRanges(ctx context.Context, id string) (*http.Response, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	return cl.Client.Call(ctx, httpclient.CallParams{
+		Method: "POST",
+		Path:   "/system/indices/ranges/index_set/" + id + "/rebuild",
+	})
+}
+
+// Stats represents the statistics of an index set or of all index sets
+type Stats struct {
+	Indices   int64 `json:"indices"`
+	Documents int64 `json:"documents"`
+	Size      int64 `json:"size"`
+}
+
+// GetStats returns the statistics of an index set.
+func (cl Client) GetStats(ctx context.Context, id string) (*Stats, *http.Response, error) {
+	if id == "" {
+		return nil, nil, errors.New("id is required")
+	}
+
+	body := &Stats{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/indices/index_sets/" + id + "/stats",
+		ResponseBody: body,
+	})
+	return body, resp, err
+}
+
+// GetGlobalStats returns the statistics of all index sets.
+func (cl Client) GetGlobalStats(ctx context.Context) (*Stats, *http.Response, error) {
+	body := &Stats{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/indices/index_sets/stats",
+		ResponseBody: body,
+	})
+	return body, resp, err
+}
@@ -0,0 +1,107 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/go-httpclient/httpclient"
+)
+
+// Client is the client of index field type profiles, which are supported by Graylog 5.1 and later.
+type Client struct {
+	Client httpclient.Client
+}
+
+func (cl Client) Get(ctx context.Context, id string) (map[string]interface{}, *http.Response, error) {
+	if id == "" {
+		return nil, nil, errors.New("id is required")
+	}
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/indices/index_sets/profiles/" + id,
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
+type GetAllParams struct {
+	Page    int
+	PerPage int
+	Query   string
+}
+
+func (params *GetAllParams) query() url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+	if params.Page != 0 {
+		query.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.PerPage != 0 {
+		query.Add("per_page", strconv.Itoa(params.PerPage))
+	}
+	if params.Query != "" {
+		query.Add("query", params.Query)
+	}
+	return query
+}
+
+func (cl Client) Gets(
+	ctx context.Context, params *GetAllParams,
+) (map[string]interface{}, *http.Response, error) {
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/indices/index_sets/profiles/paginated",
+		Query:        params.query(),
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
+func (cl Client) Create(ctx context.Context, data map[string]interface{}) (map[string]interface{}, *http.Response, error) {
+	if data == nil {
+		return nil, nil, errors.New("request body is nil")
+	}
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "POST",
+		Path:         "/system/indices/index_sets/profiles",
+		RequestBody:  data,
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
+// Update updates the profile. The profile ID is sent in the request body.
+func (cl Client) Update(ctx context.Context, id string, data map[string]interface{}) (*http.Response, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+	if data == nil {
+		return nil, errors.New("request body is nil")
+	}
+	data["id"] = id
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:      "PUT",
+		Path:        "/system/indices/index_sets/profiles",
+		RequestBody: data,
+	})
+	return resp, err
+}
+
+func (cl Client) Delete(ctx context.Context, id string) (*http.Response, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method: "DELETE",
+		Path:   "/system/indices/index_sets/profiles/" + id,
+	})
+	return resp, err
+}
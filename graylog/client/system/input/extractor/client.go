@@ -21,6 +21,16 @@ func (cl Client) Get(ctx context.Context, inputID, id string) (map[string]interf
 	return body, resp, err
 }
 
+func (cl Client) Gets(ctx context.Context, inputID string) (map[string]interface{}, *http.Response, error) {
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/inputs/" + inputID + "/extractors",
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
+
 func (cl Client) Create(
 	ctx context.Context, inputID string, data map[string]interface{},
 ) (map[string]interface{}, *http.Response, error) {
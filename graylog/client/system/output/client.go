@@ -107,3 +107,14 @@ func (cl Client) Delete(ctx context.Context, id string) (*http.Response, error)
 	})
 	return resp, err
 }
+
+// GetAvailable returns the output types which the server offers with their requested configurations.
+func (cl Client) GetAvailable(ctx context.Context) (map[string]interface{}, *http.Response, error) {
+	body := map[string]interface{}{}
+	resp, err := cl.Client.Call(ctx, httpclient.CallParams{
+		Method:       "GET",
+		Path:         "/system/outputs/available",
+		ResponseBody: &body,
+	})
+	return body, resp, err
+}
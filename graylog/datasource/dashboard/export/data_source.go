@@ -0,0 +1,31 @@
+package export
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+		Schema: map[string]*schema.Schema{
+			"dashboard_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the exported graylog_dashboard resource. The default is derived from the dashboard title.",
+			},
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The graylog_dashboard resource in the HCL syntax.",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The graylog_dashboard resource in the Terraform JSON syntax.",
+			},
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/hclgen"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/dashboard"
+)
+
+const resourceType = "graylog_dashboard"
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	id := d.Get("dashboard_id").(string)
+	values, err := dashboard.Export(ctx, cl, id)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("resource_name").(string)
+	if name == "" {
+		title, _ := values["title"].(string)
+		name = hclgen.ResourceName(title)
+	}
+	b, err := hclgen.ResourceJSON(resourceType, name, values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the dashboard %s to JSON: %w", id, err)
+	}
+
+	if err := d.Set("resource_name", name); err != nil {
+		return err
+	}
+	if err := d.Set("hcl", string(hclgen.Resource(resourceType, name, dashboard.Resource(), values))); err != nil {
+		return err
+	}
+	if err := d.Set("json", string(b)); err != nil {
+		return err
+	}
+	d.SetId(id)
+	return nil
+}
@@ -39,7 +39,7 @@ func readByID(ctx context.Context, d *schema.ResourceData, cl client.Client, id
 }
 
 func readByTitle(ctx context.Context, d *schema.ResourceData, cl client.Client, title string) error {
-	dashboards, _, err := cl.Dashboard.Gets(ctx)
+	dashboards, _, err := cl.Dashboard.Gets(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to list dashboards: %w", err)
 	}
@@ -0,0 +1,104 @@
+// Package list provides the helpers of the data sources which return lists of objects, such as graylog_streams.
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+)
+
+const keyID = "id"
+
+// ElemResource returns the resource of the elements of a list data source
+// from the schema of the data source which returns a single object.
+// All attributes are computed and id is added.
+func ElemResource(sc map[string]*schema.Schema) *schema.Resource {
+	ret := make(map[string]*schema.Schema, len(sc)+1)
+	for k, a := range sc {
+		ret[k] = &schema.Schema{
+			Type:        a.Type,
+			Computed:    true,
+			Elem:        a.Elem,
+			Description: a.Description,
+		}
+	}
+	ret[keyID] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return &schema.Resource{Schema: ret}
+}
+
+// Flatten converts an object of Graylog API to an element of a list data source.
+// Objects and arrays are converted to JSON strings if the attribute is a string, e.g. attributes of inputs.
+func Flatten(data map[string]interface{}, rsc *schema.Resource) (map[string]interface{}, error) {
+	ret := make(map[string]interface{}, len(rsc.Schema))
+	for k, sc := range rsc.Schema {
+		v, ok := data[k]
+		if !ok || v == nil {
+			continue
+		}
+		if sc.Type == schema.TypeString {
+			if _, ok := v.(string); !ok {
+				b, err := json.Marshal(v)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal the '%s' as JSON: %w", k, err)
+				}
+				v = string(b)
+			}
+			ret[k] = v
+			continue
+		}
+		a, err := convert.SetSchema(v, sc)
+		if err != nil {
+			return nil, err
+		}
+		ret[k] = a
+	}
+	return ret, nil
+}
+
+// SchemaRegex returns the schema of an optional regular expression filter.
+func SchemaRegex() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsValidRegExp,
+	}
+}
+
+// Regex returns the compiled regular expression of the filter key.
+// nil is returned if the filter isn't set.
+func Regex(d *schema.ResourceData, key string) (*regexp.Regexp, error) {
+	s, ok := d.GetOk(key)
+	if !ok {
+		return nil, nil
+	}
+	re, err := regexp.Compile(s.(string))
+	if err != nil {
+		return nil, fmt.Errorf("%s is an invalid regular expression: %w", key, err)
+	}
+	return re, nil
+}
+
+// MatchRegex returns true if the regular expression is nil or the value is a string which matches it.
+func MatchRegex(re *regexp.Regexp, v interface{}) bool {
+	if re == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && re.MatchString(s)
+}
+
+// Set sets the elements to the attribute key of the data source.
+func Set(d *schema.ResourceData, key string, elems []interface{}) error {
+	if err := d.Set(key, elems); err != nil {
+		return err
+	}
+	d.SetId(key)
+	return nil
+}
@@ -0,0 +1,65 @@
+package list
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElemResource(t *testing.T) {
+	rsc := ElemResource(map[string]*schema.Schema{
+		"title": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"title", "stream_id"},
+		},
+		"stream_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"title", "stream_id"},
+		},
+	})
+	require.Len(t, rsc.Schema, 3)
+	for k, sc := range rsc.Schema {
+		require.True(t, sc.Computed, k)
+		require.False(t, sc.Optional, k)
+		require.Empty(t, sc.ExactlyOneOf, k)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	rsc := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"title":      {Type: schema.TypeString, Computed: true},
+			"attributes": {Type: schema.TypeString, Computed: true},
+			"global":     {Type: schema.TypeBool, Computed: true},
+			"roles":      {Type: schema.TypeSet, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		},
+	}
+	elem, err := Flatten(map[string]interface{}{
+		"title":      "syslog",
+		"attributes": map[string]interface{}{"port": 514.0},
+		"global":     true,
+		"roles":      []interface{}{"Admin"},
+		"node":       nil,
+		"unknown":    "foo",
+	}, rsc)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"title":      "syslog",
+		"attributes": `{"port":514}`,
+		"global":     true,
+		"roles":      []interface{}{"Admin"},
+	}, elem)
+}
+
+func TestMatchRegex(t *testing.T) {
+	re := regexp.MustCompile("^prod-")
+	require.True(t, MatchRegex(nil, "dev-app"))
+	require.True(t, MatchRegex(re, "prod-app"))
+	require.False(t, MatchRegex(re, "dev-prod-app"))
+	require.False(t, MatchRegex(re, nil))
+}
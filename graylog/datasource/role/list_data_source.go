@@ -0,0 +1,26 @@
+package role
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func DataSourceList() *schema.Resource {
+	return &schema.Resource{
+		Read: readList,
+		Schema: map[string]*schema.Schema{
+			// filters
+			"name_regex": list.SchemaRegex(),
+			"read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     list.ElemResource(DataSource().Schema),
+			},
+		},
+	}
+}
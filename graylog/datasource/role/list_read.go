@@ -0,0 +1,47 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "name_regex")
+	if err != nil {
+		return err
+	}
+
+	roles, _, err := cl.Role.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	readOnly, filterReadOnly := d.GetOkExists(keyReadOnly)
+	rsc := DataSourceList().Schema["roles"].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, role := range roles {
+		if !list.MatchRegex(re, role[keyName]) {
+			continue
+		}
+		if filterReadOnly && role[keyReadOnly] != readOnly {
+			continue
+		}
+		// The name is the ID of the role.
+		role["id"] = role[keyName]
+		elem, err := list.Flatten(role, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, "roles", elems)
+}
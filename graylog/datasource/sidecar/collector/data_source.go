@@ -0,0 +1,122 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+
+		Schema: map[string]*schema.Schema{
+			"collector_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"collector_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"collector_id", "name"},
+			},
+			"node_operating_system": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"collector_id"},
+			},
+
+			// computed
+			"service_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"executable_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"execute_parameters": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"validation_parameters": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_template": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := d.GetOk("collector_id"); ok {
+		data, _, err := cl.Collector.Get(ctx, id.(string))
+		if err != nil {
+			return fmt.Errorf("failed to get a sidecar collector %s: %w", id, err)
+		}
+		return setDataToResourceData(d, data)
+	}
+
+	name, ok := d.GetOk("name")
+	if !ok {
+		return errors.New("one of collector_id or name must be set")
+	}
+	// The same collector name is used for each operating system, e.g. nxlog.
+	operatingSystem, filterOS := d.GetOk("node_operating_system")
+	body, _, err := cl.Collector.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list sidecar collectors: %w", err)
+	}
+	collectors, err := util.GetList(body, "collectors")
+	if err != nil {
+		return err
+	}
+	var data map[string]interface{}
+	for _, c := range collectors {
+		if n, _ := c["name"].(string); n != name {
+			continue
+		}
+		if os, _ := c["node_operating_system"].(string); filterOS && !strings.EqualFold(os, operatingSystem.(string)) {
+			continue
+		}
+		if data != nil {
+			return fmt.Errorf("name isn't unique: %s. Set node_operating_system", name)
+		}
+		data = c
+	}
+	if data == nil {
+		return fmt.Errorf("sidecar collector is not found: %s", name)
+	}
+	return setDataToResourceData(d, data)
+}
+
+func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	id, ok := util.RenameKey(data, "id", "collector_id")
+	if err := convert.SetResourceData(d, DataSource(), data); err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("the response of Graylog API is unexpected. id of the sidecar collector is empty")
+	}
+	d.SetId(id.(string))
+	return nil
+}
@@ -0,0 +1,101 @@
+package collector
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceSidecarCollector(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getList := flute.Route{
+		Name: "list sidecar collectors",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecar/collectors",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "collectors": [
+    {
+      "id": "5ec661032ab79c0012267d29",
+      "name": "filebeat",
+      "service_type": "exec",
+      "node_operating_system": "linux",
+      "executable_path": "/usr/share/filebeat/bin/filebeat"
+    },
+    {
+      "id": "5ec661032ab79c0012267d2a",
+      "name": "nxlog",
+      "service_type": "exec",
+      "node_operating_system": "linux",
+      "executable_path": "/usr/bin/nxlog"
+    },
+    {
+      "id": "5ec661032ab79c0012267d2b",
+      "name": "nxlog",
+      "service_type": "svc",
+      "node_operating_system": "windows",
+      "executable_path": "C:\\Program Files (x86)\\nxlog\\nxlog.exe"
+    }
+  ]
+}`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_sidecar_collector", DataSource()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_sidecar_collector" "filebeat" {
+  name = "filebeat"
+}
+
+data "graylog_sidecar_collector" "nxlog_windows" {
+  name                  = "nxlog"
+  node_operating_system = "windows"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_sidecar_collector.filebeat", "collector_id", "5ec661032ab79c0012267d29"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_collector.filebeat", "executable_path", "/usr/share/filebeat/bin/filebeat"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_collector.nxlog_windows", "collector_id", "5ec661032ab79c0012267d2b"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_collector.nxlog_windows", "service_type", "svc"),
+				),
+			},
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_sidecar_collector" "nxlog" {
+  name = "nxlog"
+}
+`,
+				ExpectError: regexp.MustCompile("name isn't unique: nxlog"),
+			},
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_sidecar_collector" "winlogbeat" {
+  name = "winlogbeat"
+}
+`,
+				ExpectError: regexp.MustCompile("sidecar collector is not found: winlogbeat"),
+			},
+		},
+	})
+}
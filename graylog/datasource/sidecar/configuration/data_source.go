@@ -0,0 +1,109 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	configurationClient "github.com/sven-borkert/terraform-provider-graylog/graylog/client/sidecar/configuration"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const perPage = 100
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+
+		Schema: map[string]*schema.Schema{
+			"configuration_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"configuration_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"configuration_id", "name"},
+			},
+
+			// computed
+			"collector_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"color": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"template": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+
+	id, _ := d.Get("configuration_id").(string)
+	if id == "" {
+		name, ok := d.GetOk("name")
+		if !ok {
+			return errors.New("one of configuration_id or name must be set")
+		}
+		configurations, err := util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+			body, _, err := cl.SidecarConfiguration.Gets(ctx, &configurationClient.GetAllParams{Page: page, PerPage: perPage})
+			return body, err
+		}, "configurations")
+		if err != nil {
+			return fmt.Errorf("failed to list sidecar configurations: %w", err)
+		}
+		for _, c := range configurations {
+			if n, _ := c["name"].(string); n != name {
+				continue
+			}
+			if id != "" {
+				return fmt.Errorf("name isn't unique: %s", name)
+			}
+			id, _ = c["id"].(string)
+		}
+		if id == "" {
+			return fmt.Errorf("sidecar configuration is not found: %s", name)
+		}
+	}
+
+	// The list API doesn't return the template.
+	data, _, err := cl.SidecarConfiguration.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get a sidecar configuration %s: %w", id, err)
+	}
+	return setDataToResourceData(d, data)
+}
+
+func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	id, ok := util.RenameKey(data, "id", "configuration_id")
+	if err := convert.SetResourceData(d, DataSource(), data); err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("the response of Graylog API is unexpected. id of the sidecar configuration is empty")
+	}
+	d.SetId(id.(string))
+	return nil
+}
@@ -0,0 +1,103 @@
+package configuration
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceSidecarConfiguration(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getList := flute.Route{
+		Name: "list sidecar configurations",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecar/configurations",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "total": 2,
+  "configurations": [
+    {
+      "id": "5ec709642ab79c001226edf9",
+      "name": "filebeat-linux",
+      "collector_id": "5ec661032ab79c0012267d29",
+      "color": "#ffffff"
+    },
+    {
+      "id": "5ec709642ab79c001226edfa",
+      "name": "nxlog-windows",
+      "collector_id": "5ec661032ab79c0012267d2b",
+      "color": "#000000"
+    }
+  ]
+}`,
+		},
+	}
+
+	getRoute := flute.Route{
+		Name: "get a sidecar configuration",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecar/configurations/5ec709642ab79c001226edf9",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "id": "5ec709642ab79c001226edf9",
+  "name": "filebeat-linux",
+  "collector_id": "5ec661032ab79c0012267d29",
+  "color": "#ffffff",
+  "template": "filebeat.inputs: []",
+  "tags": ["web"]
+}`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_sidecar_configuration", DataSource()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList, getRoute) },
+				Config: `
+data "graylog_sidecar_configuration" "test" {
+  name = "filebeat-linux"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_sidecar_configuration.test", "configuration_id", "5ec709642ab79c001226edf9"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_configuration.test", "collector_id", "5ec661032ab79c0012267d29"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_configuration.test", "template", "filebeat.inputs: []"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_configuration.test", "tags.#", "1"),
+				),
+			},
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList, getRoute) },
+				Config: `
+data "graylog_sidecar_configuration" "test" {
+  name = "unknown"
+}
+`,
+				ExpectError: regexp.MustCompile("sidecar configuration is not found: unknown"),
+			},
+		},
+	})
+}
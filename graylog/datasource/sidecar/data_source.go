@@ -21,6 +21,49 @@ func DataSource() *schema.Resource {
 				ExactlyOneOf:  []string{"node_id", "node_name"},
 				ConflictsWith: []string{"node_id"},
 			},
+
+			// computed
+			"operating_system": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"last_seen": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sidecar_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"assignments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"collector_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"configuration_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
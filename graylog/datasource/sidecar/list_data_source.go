@@ -0,0 +1,40 @@
+package sidecar
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func DataSourceList() *schema.Resource {
+	return &schema.Resource{
+		Read: readList,
+		Schema: map[string]*schema.Schema{
+			// filters
+			"node_name_regex": list.SchemaRegex(),
+			"operating_system": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"last_seen_within": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+
+			"sidecars": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     list.ElemResource(DataSource().Schema),
+			},
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package sidecar
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceSidecars(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	getList := flute.Route{
+		Name: "list sidecars",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecars/all",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: fmt.Sprintf(`{
+  "sidecars": [
+    {
+      "node_id": "10517347-8fad-4f25-835f-95d1943fa338",
+      "node_name": "web-1",
+      "active": true,
+      "node_details": {
+        "operating_system": "Linux",
+        "ip": "172.18.0.2",
+        "tags": ["web", "prod"]
+      },
+      "assignments": [
+        {
+          "collector_id": "5ec661032ab79c0012267d29",
+          "configuration_id": "5ec709642ab79c001226edf9"
+        }
+      ],
+      "last_seen": %q,
+      "sidecar_version": "1.5.0"
+    },
+    {
+      "node_id": "20517347-8fad-4f25-835f-95d1943fa338",
+      "node_name": "web-2",
+      "active": false,
+      "node_details": {
+        "operating_system": "Linux",
+        "ip": "172.18.0.3",
+        "tags": ["web"]
+      },
+      "last_seen": %q,
+      "sidecar_version": "1.5.0"
+    },
+    {
+      "node_id": "30517347-8fad-4f25-835f-95d1943fa338",
+      "node_name": "dc-1",
+      "active": true,
+      "node_details": {
+        "operating_system": "Windows",
+        "ip": "172.18.0.4"
+      },
+      "last_seen": %q,
+      "sidecar_version": "1.5.0"
+    }
+  ]
+}`,
+				now.Add(-time.Minute).Format(time.RFC3339Nano),
+				now.Add(-48*time.Hour).Format(time.RFC3339Nano),
+				now.Format(time.RFC3339Nano)),
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_sidecars", DataSourceList()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_sidecars" "linux" {
+  operating_system = "linux"
+}
+
+data "graylog_sidecars" "prod" {
+  tags = ["web", "prod"]
+}
+
+data "graylog_sidecars" "inactive" {
+  active = false
+}
+
+data "graylog_sidecars" "recent_web" {
+  node_name_regex  = "^web-"
+  last_seen_within = "1h"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_sidecars.linux", "sidecars.#", "2"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.linux", "sidecars.0.node_name", "web-1"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.linux", "sidecars.0.id", "10517347-8fad-4f25-835f-95d1943fa338"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.linux", "sidecars.0.ip", "172.18.0.2"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.linux", "sidecars.0.assignments.0.configuration_id", "5ec709642ab79c001226edf9"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.prod", "sidecars.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.inactive", "sidecars.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.inactive", "sidecars.0.node_name", "web-2"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.recent_web", "sidecars.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_sidecars.recent_web", "sidecars.0.node_name", "web-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateDuration(t *testing.T) {
+	_, errs := validateDuration("24h", "last_seen_within")
+	require.Empty(t, errs)
+	_, errs = validateDuration("1d", "last_seen_within")
+	require.Len(t, errs, 1)
+}
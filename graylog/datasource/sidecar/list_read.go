@@ -0,0 +1,112 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := time.ParseDuration(s); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration such as 10m or 24h: %w", k, err)}
+	}
+	return nil, nil
+}
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "node_name_regex")
+	if err != nil {
+		return err
+	}
+	var since time.Time
+	if s, ok := d.GetOk("last_seen_within"); ok {
+		within, err := time.ParseDuration(s.(string))
+		if err != nil {
+			return fmt.Errorf("last_seen_within is invalid: %w", err)
+		}
+		since = time.Now().Add(-within)
+	}
+
+	body, _, err := cl.Sidecar.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list sidecars: %w", err)
+	}
+	sidecars, err := util.GetList(body, keySidecars)
+	if err != nil {
+		return err
+	}
+
+	operatingSystem, filterOS := d.GetOk(keyOperatingSystem)
+	tags := d.Get(keyTags).(*schema.Set).List()
+	active, filterActive := d.GetOkExists(keyActive)
+	rsc := DataSourceList().Schema[keySidecars].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, sidecar := range sidecars {
+		sidecar = flatten(sidecar)
+		if !list.MatchRegex(re, sidecar[keyNodeName]) {
+			continue
+		}
+		if os, _ := sidecar[keyOperatingSystem].(string); filterOS && !strings.EqualFold(os, operatingSystem.(string)) {
+			continue
+		}
+		if !hasTags(sidecar, tags) {
+			continue
+		}
+		if a, _ := sidecar[keyActive].(bool); filterActive && a != active.(bool) {
+			continue
+		}
+		if !since.IsZero() && !seenSince(sidecar, since) {
+			continue
+		}
+		sidecar["id"] = sidecar[keyNodeID]
+		elem, err := list.Flatten(sidecar, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, keySidecars, elems)
+}
+
+// hasTags returns true if the sidecar has all tags.
+func hasTags(sidecar map[string]interface{}, tags []interface{}) bool {
+	sidecarTags, _ := sidecar[keyTags].([]interface{})
+	for _, tag := range tags {
+		found := false
+		for _, t := range sidecarTags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// seenSince returns true if the sidecar was last seen at or after since.
+func seenSince(sidecar map[string]interface{}, since time.Time) bool {
+	s, _ := sidecar[keyLastSeen].(string)
+	lastSeen, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return false
+	}
+	return !lastSeen.Before(since)
+}
@@ -0,0 +1,157 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyNodeIDs        = "node_ids"
+	keyCollectorIDs   = "collector_ids"
+	keyCollectors     = "collectors"
+	keyNodeID         = "node_id"
+	keyNodeName       = "node_name"
+	keyCollectorID    = "collector_id"
+	keyStatus         = "status"
+	keyStatusCode     = "status_code"
+	keyMessage        = "message"
+	keyVerboseMessage = "verbose_message"
+)
+
+// statusNames are the names of the collector status codes of Graylog.
+var statusNames = map[int]string{
+	0: "running",
+	1: "unknown",
+	2: "failing",
+	3: "stopped",
+}
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+
+		Schema: map[string]*schema.Schema{
+			// filters
+			keyNodeIDs: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyCollectorIDs: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			keyCollectors: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyNodeID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyNodeName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyCollectorID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyStatus: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyStatusCode: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						keyMessage: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyVerboseMessage: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+
+	body, _, err := cl.Sidecar.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list sidecars: %w", err)
+	}
+	sidecars, err := util.GetList(body, "sidecars")
+	if err != nil {
+		return err
+	}
+
+	nodeIDs := d.Get(keyNodeIDs).(*schema.Set)
+	collectorIDs := d.Get(keyCollectorIDs).(*schema.Set)
+	collectors := []map[string]interface{}{}
+	for _, sidecar := range sidecars {
+		nodeID, _ := sidecar[keyNodeID].(string)
+		if nodeIDs.Len() != 0 && !nodeIDs.Contains(nodeID) {
+			continue
+		}
+		details, _ := sidecar["node_details"].(map[string]interface{})
+		status, _ := details[keyStatus].(map[string]interface{})
+		statuses, _ := status[keyCollectors].([]interface{})
+		for _, a := range statuses {
+			collector, ok := a.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			collectorID, _ := collector[keyCollectorID].(string)
+			if collectorIDs.Len() != 0 && !collectorIDs.Contains(collectorID) {
+				continue
+			}
+			code, _ := collector[keyStatus].(float64)
+			name, ok := statusNames[int(code)]
+			if !ok {
+				name = statusNames[1]
+			}
+			collectors = append(collectors, map[string]interface{}{
+				keyNodeID:         nodeID,
+				keyNodeName:       sidecar[keyNodeName],
+				keyCollectorID:    collectorID,
+				keyStatus:         name,
+				keyStatusCode:     int(code),
+				keyMessage:        collector[keyMessage],
+				keyVerboseMessage: collector[keyVerboseMessage],
+			})
+		}
+	}
+	sort.SliceStable(collectors, func(i, j int) bool {
+		a, b := collectors[i], collectors[j]
+		if a[keyNodeID] != b[keyNodeID] {
+			return a[keyNodeID].(string) < b[keyNodeID].(string)
+		}
+		return a[keyCollectorID].(string) < b[keyCollectorID].(string)
+	})
+
+	elems := make([]interface{}, len(collectors))
+	for i, c := range collectors {
+		elems[i] = c
+	}
+	return list.Set(d, keyCollectors, elems)
+}
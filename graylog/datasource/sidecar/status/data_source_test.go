@@ -0,0 +1,118 @@
+package status
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceSidecarStatus(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getRoute := flute.Route{
+		Name: "get sidecars",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecars/all",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "sidecars": [
+    {
+      "node_id": "20517347-8fad-4f25-835f-95d1943fa338",
+      "node_name": "web-2",
+      "node_details": {
+        "status": {
+          "status": 0,
+          "collectors": [
+            {
+              "collector_id": "5ec661032ab79c0012267d29",
+              "status": 0,
+              "message": "Running",
+              "verbose_message": ""
+            }
+          ]
+        }
+      }
+    },
+    {
+      "node_id": "10517347-8fad-4f25-835f-95d1943fa338",
+      "node_name": "web-1",
+      "node_details": {
+        "status": {
+          "status": 2,
+          "collectors": [
+            {
+              "collector_id": "5ec661032ab79c0012267d2a",
+              "status": 3,
+              "message": "Stopped"
+            },
+            {
+              "collector_id": "5ec661032ab79c0012267d29",
+              "status": 2,
+              "message": "Failed to find collector executable",
+              "verbose_message": "stat /usr/share/filebeat/bin/filebeat: no such file or directory"
+            }
+          ]
+        }
+      }
+    },
+    {
+      "node_id": "30517347-8fad-4f25-835f-95d1943fa338",
+      "node_name": "new"
+    }
+  ]
+}`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_sidecar_status", DataSource()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getRoute) },
+				// the sidecar without status is ignored
+				Config: `
+data "graylog_sidecar_status" "all" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.#", "3"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.0.node_name", "web-1"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.0.collector_id", "5ec661032ab79c0012267d29"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.0.status", "failing"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.0.status_code", "2"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.0.verbose_message", "stat /usr/share/filebeat/bin/filebeat: no such file or directory"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.1.status", "stopped"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.all", "collectors.2.status", "running"),
+				),
+			},
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getRoute) },
+				Config: `
+data "graylog_sidecar_status" "web_2" {
+  node_ids = ["20517347-8fad-4f25-835f-95d1943fa338"]
+}
+
+data "graylog_sidecar_status" "filebeat" {
+  collector_ids = ["5ec661032ab79c0012267d29"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.web_2", "collectors.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_sidecar_status.filebeat", "collectors.#", "2"),
+				),
+			},
+		},
+	})
+}
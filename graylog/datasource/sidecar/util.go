@@ -8,20 +8,38 @@ import (
 )
 
 const (
-	keyNodeID   = "node_id"
-	keyNodeName = "node_name"
-	keySidecars = "sidecars"
+	keyNodeID          = "node_id"
+	keyNodeName        = "node_name"
+	keyNodeDetails     = "node_details"
+	keyOperatingSystem = "operating_system"
+	keyIP              = "ip"
+	keyTags            = "tags"
+	keyActive          = "active"
+	keyLastSeen        = "last_seen"
+	keySidecars        = "sidecars"
 )
 
 var errNodeIDNotFound = errors.New("node_id isn't found")
 
+// flatten moves the attributes of node_details to the top level.
+func flatten(data map[string]interface{}) map[string]interface{} {
+	details, _ := data[keyNodeDetails].(map[string]interface{})
+	for _, k := range []string{keyOperatingSystem, keyIP, keyTags} {
+		if v, ok := details[k]; ok {
+			data[k] = v
+		}
+	}
+	delete(data, keyNodeDetails)
+	return data
+}
+
 func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
 	id, ok := data[keyNodeID]
 	if !ok {
 		return errNodeIDNotFound
 	}
 
-	if err := convert.SetResourceData(d, DataSource(), data); err != nil {
+	if err := convert.SetResourceData(d, DataSource(), flatten(data)); err != nil {
 		return err
 	}
 
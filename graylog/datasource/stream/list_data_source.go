@@ -0,0 +1,30 @@
+package stream
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func DataSourceList() *schema.Resource {
+	return &schema.Resource{
+		Read: readList,
+		Schema: map[string]*schema.Schema{
+			// filters
+			"title_regex": list.SchemaRegex(),
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"index_set_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"streams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     list.ElemResource(DataSource().Schema),
+			},
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package stream
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceStreams(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getList := flute.Route{
+		Name: "list streams",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/streams",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "total": 4,
+  "streams": [
+    {
+      "id": "000000000000000000000001",
+      "title": "Default Stream",
+      "index_set_id": "5ea25a282ab79c00125200b9",
+      "disabled": false,
+      "is_default": true
+    },
+    {
+      "id": "5ea26bb42ab79c0012521287",
+      "title": "prod-app",
+      "index_set_id": "5ea25a282ab79c00125200b9",
+      "disabled": false,
+      "matching_type": "AND"
+    },
+    {
+      "id": "5ea26bb42ab79c0012521288",
+      "title": "prod-db",
+      "index_set_id": "5ea25a282ab79c00125200ba",
+      "disabled": true
+    },
+    {
+      "id": "5ea26bb42ab79c0012521289",
+      "title": "dev-app",
+      "index_set_id": "5ea25a282ab79c00125200b9",
+      "disabled": false
+    }
+  ]
+}`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_streams", DataSourceList()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_streams" "prod" {
+  title_regex = "^prod-"
+}
+
+data "graylog_streams" "prod_enabled" {
+  title_regex = "^prod-"
+  disabled    = false
+}
+
+data "graylog_streams" "index_set" {
+  index_set_id = "5ea25a282ab79c00125200b9"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_streams.prod", "streams.#", "2"),
+					resource.TestCheckResourceAttr("data.graylog_streams.prod", "streams.0.id", "5ea26bb42ab79c0012521287"),
+					resource.TestCheckResourceAttr("data.graylog_streams.prod", "streams.0.stream_id", "5ea26bb42ab79c0012521287"),
+					resource.TestCheckResourceAttr("data.graylog_streams.prod", "streams.0.matching_type", "AND"),
+					resource.TestCheckResourceAttr("data.graylog_streams.prod_enabled", "streams.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_streams.prod_enabled", "streams.0.title", "prod-app"),
+					resource.TestCheckResourceAttr("data.graylog_streams.index_set", "streams.#", "3"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "title_regex")
+	if err != nil {
+		return err
+	}
+
+	body, _, err := cl.Stream.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list streams: %w", err)
+	}
+	streams, err := util.GetList(body, "streams")
+	if err != nil {
+		return err
+	}
+
+	disabled, filterDisabled := d.GetOkExists("disabled")
+	indexSetID, filterIndexSet := d.GetOk("index_set_id")
+	rsc := DataSourceList().Schema["streams"].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, stream := range streams {
+		if !list.MatchRegex(re, stream["title"]) {
+			continue
+		}
+		if filterDisabled && stream["disabled"] != disabled {
+			continue
+		}
+		if filterIndexSet && stream["index_set_id"] != indexSetID {
+			continue
+		}
+		stream["stream_id"] = stream["id"]
+		elem, err := list.Flatten(stream, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, "streams", elems)
+}
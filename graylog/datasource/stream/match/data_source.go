@@ -0,0 +1,219 @@
+package match
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
+)
+
+const (
+	keyStreamID     = "stream_id"
+	keyRule         = "rule"
+	keyMatchingType = "matching_type"
+	keyMessage      = "message"
+	keyMatches      = "matches"
+	keyRules        = "rules"
+	keyID           = "id"
+	keyType         = "type"
+	keyOperator     = "operator"
+	keyField        = "field"
+	keyValue        = "value"
+	keyInverted     = "inverted"
+	keyMatched      = "matched"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+
+		Schema: map[string]*schema.Schema{
+			keyStreamID: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{keyStreamID, keyRule},
+			},
+			keyRule: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyOperator: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(rule.OperatorNames(), false),
+						},
+						keyField: {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						keyValue: {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						keyInverted: {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			keyMatchingType: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{keyStreamID},
+				ValidateFunc:  validation.StringInSlice([]string{"AND", "OR"}, false),
+			},
+			keyMessage: {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			keyMatches: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			keyRules: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyOperator: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyField: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyValue: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyInverted: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						keyMatched: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	message := d.Get(keyMessage).(map[string]interface{})
+	var (
+		matches bool
+		rules   []interface{}
+		err     error
+	)
+	if streamID, ok := d.GetOk(keyStreamID); ok {
+		matches, rules, err = testStream(context.Background(), m, streamID.(string), message)
+		if err != nil {
+			return err
+		}
+		d.SetId(streamID.(string))
+	} else {
+		matches, rules, err = testRules(d.Get(keyRule).([]interface{}), d.Get(keyMatchingType).(string), message)
+		if err != nil {
+			return err
+		}
+		d.SetId(keyRule)
+	}
+	if err := d.Set(keyMatches, matches); err != nil {
+		return err
+	}
+	return d.Set(keyRules, rules)
+}
+
+// testStream tests the message with the testMatch API of the stream.
+func testStream(ctx context.Context, m interface{}, streamID string, message map[string]interface{}) (bool, []interface{}, error) {
+	cl, err := client.New(m)
+	if err != nil {
+		return false, nil, err
+	}
+	stream, _, err := cl.Stream.Get(ctx, streamID)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get a stream %s: %w", streamID, err)
+	}
+	body, _, err := cl.Stream.TestMatch(ctx, streamID, message)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to test a message against the stream %s: %w", streamID, err)
+	}
+	matches, ok := body[keyMatches].(bool)
+	if !ok {
+		return false, nil, errors.New("the response of Graylog API is unexpected. matches isn't a boolean")
+	}
+	results, _ := body[keyRules].(map[string]interface{})
+
+	streamRules, _ := stream[keyRules].([]interface{})
+	rules := make([]interface{}, 0, len(streamRules))
+	for _, a := range streamRules {
+		r, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := r[keyID].(string)
+		t, _ := r[keyType].(float64)
+		operator, _ := rule.OperatorName(int(t))
+		matched, _ := results[id].(bool)
+		rules = append(rules, map[string]interface{}{
+			keyID:       id,
+			keyOperator: operator,
+			keyField:    r[keyField],
+			keyValue:    r[keyValue],
+			keyInverted: r[keyInverted],
+			keyMatched:  matched,
+		})
+	}
+	return matches, rules, nil
+}
+
+// testRules evaluates the rules against the message without Graylog.
+// As with streams, a message matches an empty list of rules neither with AND nor with OR.
+func testRules(blocks []interface{}, matchingType string, message map[string]interface{}) (bool, []interface{}, error) {
+	rules := make([]interface{}, len(blocks))
+	allMatched, anyMatched := len(blocks) != 0, false
+	for i, a := range blocks {
+		r := a.(map[string]interface{})
+		operator, _ := r[keyOperator].(string)
+		field, _ := r[keyField].(string)
+		value, _ := r[keyValue].(string)
+		inverted, _ := r[keyInverted].(bool)
+		if err := rule.Validate(operator, field, value); err != nil {
+			return false, nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		matched, err := rule.Match(operator, field, value, inverted, message)
+		if err != nil {
+			return false, nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		allMatched = allMatched && matched
+		anyMatched = anyMatched || matched
+		rules[i] = map[string]interface{}{
+			keyID:       "",
+			keyOperator: operator,
+			keyField:    field,
+			keyValue:    value,
+			keyInverted: inverted,
+			keyMatched:  matched,
+		}
+	}
+	if matchingType == "OR" {
+		return anyMatched, rules, nil
+	}
+	return allMatched, rules, nil
+}
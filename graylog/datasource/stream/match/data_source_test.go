@@ -0,0 +1,139 @@
+package match
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceStreamMatchTest(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getRoute := flute.Route{
+		Name: "get a stream",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/streams/5ea26bb42ab79c0012521287",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "id": "5ea26bb42ab79c0012521287",
+  "rules": [
+    {
+      "id": "5ea26bb42ab79c0012521288",
+      "type": 1,
+      "field": "source",
+      "value": "nginx",
+      "inverted": false
+    },
+    {
+      "id": "5ea26bb42ab79c0012521289",
+      "type": 5,
+      "field": "request",
+      "value": "",
+      "inverted": false
+    }
+  ]
+}`,
+		},
+	}
+
+	testMatchRoute := flute.Route{
+		Name: "test a message against the stream",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/streams/5ea26bb42ab79c0012521287/testMatch",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				body := map[string]interface{}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				require.Equal(t, map[string]interface{}{"source": "nginx"}, body["message"])
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "matches": false,
+  "rules": {
+    "5ea26bb42ab79c0012521288": true,
+    "5ea26bb42ab79c0012521289": false
+  }
+}`,
+		},
+	}
+
+	dataSourceName := "data.graylog_stream_match_test.nginx"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_stream_match_test", DataSource()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getRoute, testMatchRoute) },
+				Config: `
+data "graylog_stream_match_test" "nginx" {
+  stream_id = "5ea26bb42ab79c0012521287"
+  message = {
+    source = "nginx"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "matches", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "rules.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "rules.0.operator", "equals"),
+					resource.TestCheckResourceAttr(dataSourceName, "rules.0.matched", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "rules.1.operator", "present"),
+					resource.TestCheckResourceAttr(dataSourceName, "rules.1.matched", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestTestRules(t *testing.T) {
+	message := map[string]interface{}{"source": "nginx", "http_status": "502", "request": "GET /api/users"}
+	rules := []interface{}{
+		map[string]interface{}{"operator": "equals", "field": "source", "value": "nginx", "inverted": false},
+		map[string]interface{}{"operator": "greater", "field": "http_status", "value": "499", "inverted": false},
+		map[string]interface{}{"operator": "regex", "field": "request", "value": "^POST ", "inverted": false},
+	}
+
+	matches, results, err := testRules(rules, "", message)
+	require.Nil(t, err)
+	require.False(t, matches)
+	require.Len(t, results, 3)
+	require.True(t, results[0].(map[string]interface{})["matched"].(bool))
+	require.False(t, results[2].(map[string]interface{})["matched"].(bool))
+
+	matches, _, err = testRules(rules, "OR", message)
+	require.Nil(t, err)
+	require.True(t, matches)
+
+	matches, _, err = testRules(nil, "OR", message)
+	require.Nil(t, err)
+	require.False(t, matches)
+
+	_, _, err = testRules([]interface{}{
+		map[string]interface{}{"operator": "regex", "field": "request", "value": "^(?!GET)", "inverted": false},
+	}, "", message)
+	require.NotNil(t, err)
+}
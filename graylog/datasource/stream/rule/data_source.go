@@ -25,6 +25,10 @@ func DataSource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"operator": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"value": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -13,6 +13,11 @@ const (
 )
 
 func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	if t, ok := data["type"].(float64); ok {
+		if operator, ok := ruleRes.OperatorName(int(t)); ok {
+			data["operator"] = operator
+		}
+	}
 	if err := convert.SetResourceData(d, ruleRes.Resource(), data); err != nil {
 		return err
 	}
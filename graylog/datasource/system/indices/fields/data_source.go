@@ -0,0 +1,124 @@
+package fields
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	ftClient "github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/fieldtype"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const perPage = 100
+
+// Sources of the field types.
+const (
+	sourceCustom       = "custom"
+	sourceProfile      = "profile"
+	sourceIndexMapping = "index_mapping"
+)
+
+// sources maps the origins of Graylog API to the sources of the data source.
+var sources = map[string]string{
+	ftClient.OriginOverriddenIndex:   sourceCustom,
+	ftClient.OriginOverriddenProfile: sourceProfile,
+	ftClient.OriginProfile:           sourceProfile,
+	ftClient.OriginIndex:             sourceIndexMapping,
+}
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+
+		Schema: map[string]*schema.Schema{
+			"index_set_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// computed
+			"fields": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"origin": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reserved": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"field_types": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+
+	indexSetID := d.Get("index_set_id").(string)
+	elements, err := util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.FieldType.GetFieldTypes(ctx, indexSetID, &ftClient.GetFieldTypesParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "elements")
+	if err != nil {
+		return fmt.Errorf("failed to get the field types of the index set %s: %w", indexSetID, err)
+	}
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		a, _ := elements[i]["field_name"].(string)
+		b, _ := elements[j]["field_name"].(string)
+		return a < b
+	})
+	fields := make([]interface{}, len(elements))
+	fieldTypes := make(map[string]interface{}, len(elements))
+	for i, elem := range elements {
+		name, _ := elem["field_name"].(string)
+		origin, _ := elem["origin"].(string)
+		reserved, _ := elem["is_reserved"].(bool)
+		fields[i] = map[string]interface{}{
+			"name":     name,
+			"type":     elem["type"],
+			"source":   sources[origin],
+			"origin":   origin,
+			"reserved": reserved,
+		}
+		fieldTypes[name] = elem["type"]
+	}
+
+	if err := d.Set("fields", fields); err != nil {
+		return err
+	}
+	if err := d.Set("field_types", fieldTypes); err != nil {
+		return err
+	}
+	d.SetId(indexSetID)
+	return nil
+}
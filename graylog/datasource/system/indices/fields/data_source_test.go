@@ -0,0 +1,92 @@
+package fields
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceIndexSetFields(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getRoute := flute.Route{
+		Name: "get the field types of an index set",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/system/indices/index_sets/types/5ea25a282ab79c00125200b9",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "total": 4,
+  "elements": [
+    {
+      "field_name": "timestamp",
+      "type": "date",
+      "origin": "INDEX",
+      "is_reserved": true
+    },
+    {
+      "field_name": "http_status",
+      "type": "long",
+      "origin": "OVERRIDDEN_INDEX",
+      "is_reserved": false
+    },
+    {
+      "field_name": "client_ip",
+      "type": "ip",
+      "origin": "OVERRIDDEN_PROFILE",
+      "is_reserved": false
+    },
+    {
+      "field_name": "user_agent",
+      "type": "string",
+      "origin": "PROFILE",
+      "is_reserved": false
+    }
+  ]
+}`,
+		},
+	}
+
+	dataSourceName := "data.graylog_index_set_fields.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_index_set_fields", DataSource()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getRoute) },
+				Config: `
+data "graylog_index_set_fields" "test" {
+  index_set_id = "5ea25a282ab79c00125200b9"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "5ea25a282ab79c00125200b9"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.0.name", "client_ip"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.0.source", "profile"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.1.name", "http_status"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.1.source", "custom"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.2.name", "timestamp"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.2.source", "index_mapping"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.2.reserved", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.3.name", "user_agent"),
+					resource.TestCheckResourceAttr(dataSourceName, "fields.3.source", "profile"),
+					resource.TestCheckResourceAttr(dataSourceName, "field_types.%", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "field_types.http_status", "long"),
+				),
+			},
+		},
+	})
+}
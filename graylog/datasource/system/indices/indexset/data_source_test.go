@@ -227,3 +227,27 @@ data "graylog_index_set" "test" {
 		},
 	})
 }
+
+func TestSetDataToResourceData(t *testing.T) {
+	d := DataSource().Data(nil)
+	err := setDataToResourceData(d, map[string]interface{}{
+		"id":           "5ea81cb42ab79c00129dbe58",
+		"title":        "Default index set",
+		"data_tiering": map[string]interface{}{"type": "hot_only", "index_lifetime_min": "P30D", "index_lifetime_max": "P40D"},
+		"field_restrictions": map[string]interface{}{
+			"source": []interface{}{map[string]interface{}{"type": "immutable"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "5ea81cb42ab79c00129dbe58" {
+		t.Fatalf("id = %s", d.Id())
+	}
+	if v := d.Get("data_tiering"); v != `{"index_lifetime_max":"P40D","index_lifetime_min":"P30D","type":"hot_only"}` {
+		t.Fatalf("data_tiering = %v", v)
+	}
+	if v := d.Get("field_restrictions"); v != `{"source":[{"type":"immutable"}]}` {
+		t.Fatalf("field_restrictions = %v", v)
+	}
+}
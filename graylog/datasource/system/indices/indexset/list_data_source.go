@@ -0,0 +1,22 @@
+package indexset
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func DataSourceList() *schema.Resource {
+	return &schema.Resource{
+		Read: readList,
+		Schema: map[string]*schema.Schema{
+			// filters
+			"title_regex": list.SchemaRegex(),
+
+			"index_sets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     list.ElemResource(DataSource().Schema),
+			},
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package indexset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "title_regex")
+	if err != nil {
+		return err
+	}
+
+	body, _, err := cl.IndexSet.Gets(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list index sets: %w", err)
+	}
+	indexSets, err := util.GetList(body, "index_sets")
+	if err != nil {
+		return err
+	}
+
+	rsc := DataSourceList().Schema["index_sets"].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, indexSet := range indexSets {
+		if !list.MatchRegex(re, indexSet["title"]) {
+			continue
+		}
+		indexSet["index_set_id"] = indexSet["id"]
+		elem, err := list.Flatten(indexSet, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, "index_sets", elems)
+}
@@ -12,6 +12,15 @@ func setDataToResourceData(d *schema.ResourceData, data map[string]interface{})
 	if err := convert.DataToJSON(data, "rotation_strategy", "retention_strategy"); err != nil {
 		return err
 	}
+	// data_tiering and field_restrictions are JSON strings in the data source.
+	for _, key := range []string{"data_tiering", "field_restrictions"} {
+		if _, ok := data[key]; !ok {
+			continue
+		}
+		if err := convert.DataToJSON(data, key); err != nil {
+			return err
+		}
+	}
 
 	if err := convert.SetResourceData(d, indexset.Resource(), data); err != nil {
 		return err
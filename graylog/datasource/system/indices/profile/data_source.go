@@ -0,0 +1,119 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	profileClient "github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/profile"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/indices/profile"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const perPage = 100
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+
+		Schema: map[string]*schema.Schema{
+			"profile_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"profile_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"profile_id", "name"},
+			},
+
+			// computed
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"custom_field_mapping": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"index_set_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+
+	if id, ok := d.GetOk("profile_id"); ok {
+		data, _, err := cl.IndexFieldTypeProfile.Get(ctx, id.(string))
+		if err != nil {
+			return fmt.Errorf("failed to get an index field type profile %s: %w", id, err)
+		}
+		return setDataToResourceData(d, data)
+	}
+
+	name, ok := d.GetOk("name")
+	if !ok {
+		return errors.New("one of profile_id or name must be set")
+	}
+	profiles, err := util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.IndexFieldTypeProfile.Gets(ctx, &profileClient.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "elements")
+	if err != nil {
+		return fmt.Errorf("failed to list index field type profiles: %w", err)
+	}
+	var data map[string]interface{}
+	for _, p := range profiles {
+		if n, _ := p["name"].(string); n != name {
+			continue
+		}
+		if data != nil {
+			return fmt.Errorf("name isn't unique: %s", name)
+		}
+		data = p
+	}
+	if data == nil {
+		return fmt.Errorf("index field type profile is not found: %s", name)
+	}
+	return setDataToResourceData(d, data)
+}
+
+func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	id, ok := util.RenameKey(data, "id", "profile_id")
+	util.RenameKey(data, "custom_field_mappings", "custom_field_mapping")
+	if err := convert.SetResourceData(d, profile.Resource(), data); err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("the response of Graylog API is unexpected. id of the index field type profile is empty")
+	}
+	d.SetId(id.(string))
+	return d.Set("profile_id", id)
+}
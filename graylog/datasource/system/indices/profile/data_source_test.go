@@ -0,0 +1,95 @@
+package profile
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceIndexFieldTypeProfileByName(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getList := flute.Route{
+		Name: "list index field type profiles",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/system/indices/index_sets/profiles/paginated",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "total": 3,
+  "elements": [
+    {
+      "id": "6500f2e5c1b1d5263a4e0a33",
+      "name": "web",
+      "description": "web servers",
+      "custom_field_mappings": [
+        {
+          "field": "http_status",
+          "type": "long"
+        }
+      ],
+      "index_set_ids": []
+    },
+    {
+      "id": "6500f2e5c1b1d5263a4e0a34",
+      "name": "dup"
+    },
+    {
+      "id": "6500f2e5c1b1d5263a4e0a35",
+      "name": "dup"
+    }
+  ]
+}`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_index_field_type_profile", DataSource()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_index_field_type_profile" "web" {
+  name = "web"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_index_field_type_profile.web", "profile_id", "6500f2e5c1b1d5263a4e0a33"),
+					resource.TestCheckResourceAttr("data.graylog_index_field_type_profile.web", "description", "web servers"),
+					resource.TestCheckResourceAttr("data.graylog_index_field_type_profile.web", "custom_field_mapping.#", "1"),
+				),
+			},
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_index_field_type_profile" "dup" {
+  name = "dup"
+}
+`,
+				ExpectError: regexp.MustCompile("name isn't unique: dup"),
+			},
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_index_field_type_profile" "missing" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile("index field type profile is not found: missing"),
+			},
+		},
+	})
+}
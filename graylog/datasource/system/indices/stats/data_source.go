@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/indexset"
+)
+
+// globalID is the ID of the data source if index_set_id isn't set.
+const globalID = "all"
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: read,
+
+		Schema: map[string]*schema.Schema{
+			// The statistics of all index sets are returned if index_set_id isn't set.
+			"index_set_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// computed
+			"indices": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"documents": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+
+	var stats *indexset.Stats
+	id := globalID
+	if indexSetID, ok := d.GetOk("index_set_id"); ok {
+		id = indexSetID.(string)
+		stats, _, err = cl.IndexSet.GetStats(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get the statistics of the index set %s: %w", id, err)
+		}
+	} else {
+		stats, _, err = cl.IndexSet.GetGlobalStats(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the statistics of all index sets: %w", err)
+		}
+	}
+
+	if err := d.Set("indices", stats.Indices); err != nil {
+		return err
+	}
+	if err := d.Set("documents", stats.Documents); err != nil {
+		return err
+	}
+	if err := d.Set("size", stats.Size); err != nil {
+		return err
+	}
+	d.SetId(id)
+	return nil
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceIndexSetStats(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getRoute := flute.Route{
+		Name: "get the stats of an index set",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/system/indices/index_sets/5ea25a282ab79c00125200b9/stats",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "indices": 4,
+  "documents": 120000,
+  "size": 53687091200
+}`,
+		},
+	}
+
+	getAllRoute := flute.Route{
+		Name: "get the stats of all index sets",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/system/indices/index_sets/stats",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "indices": 10,
+  "documents": 500000,
+  "size": 107374182400
+}`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_index_set_stats", DataSource()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getRoute, getAllRoute) },
+				Config: `
+data "graylog_index_set_stats" "test" {
+  index_set_id = "5ea25a282ab79c00125200b9"
+}
+
+data "graylog_index_set_stats" "all" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_index_set_stats.test", "id", "5ea25a282ab79c00125200b9"),
+					resource.TestCheckResourceAttr("data.graylog_index_set_stats.test", "indices", "4"),
+					resource.TestCheckResourceAttr("data.graylog_index_set_stats.test", "documents", "120000"),
+					resource.TestCheckResourceAttr("data.graylog_index_set_stats.test", "size", "53687091200"),
+					resource.TestCheckResourceAttr("data.graylog_index_set_stats.all", "id", "all"),
+					resource.TestCheckResourceAttr("data.graylog_index_set_stats.all", "indices", "10"),
+					resource.TestCheckResourceAttr("data.graylog_index_set_stats.all", "size", "107374182400"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package input
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func DataSourceList() *schema.Resource {
+	return &schema.Resource{
+		Read: readList,
+		Schema: map[string]*schema.Schema{
+			// filters
+			"title_regex": list.SchemaRegex(),
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"inputs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     list.ElemResource(DataSource().Schema),
+			},
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package input
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "title_regex")
+	if err != nil {
+		return err
+	}
+
+	body, _, err := cl.Input.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list inputs: %w", err)
+	}
+	inputs, err := util.GetList(body, "inputs")
+	if err != nil {
+		return err
+	}
+
+	inputType, filterType := d.GetOk("type")
+	rsc := DataSourceList().Schema["inputs"].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, input := range inputs {
+		if !list.MatchRegex(re, input["title"]) {
+			continue
+		}
+		if filterType && input["type"] != inputType {
+			continue
+		}
+		normalizeConfiguration(input)
+		input["input_id"] = input["id"]
+		elem, err := list.Flatten(input, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, "inputs", elems)
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "title_regex")
+	if err != nil {
+		return err
+	}
+
+	body, _, err := cl.Output.Gets(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list outputs: %w", err)
+	}
+	outputs, err := util.GetList(body, "outputs")
+	if err != nil {
+		return err
+	}
+
+	outputType, filterType := d.GetOk("type")
+	rsc := DataSourceList().Schema["outputs"].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, output := range outputs {
+		if !list.MatchRegex(re, output["title"]) {
+			continue
+		}
+		if filterType && output["type"] != outputType {
+			continue
+		}
+		output["output_id"] = output["id"]
+		elem, err := list.Flatten(output, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, "outputs", elems)
+}
@@ -0,0 +1,41 @@
+package output
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: readTypes,
+		Schema: map[string]*schema.Schema{
+			"types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"human_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"link_to_docs": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"requested_configuration": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceOutputTypes(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{
+  "types": {
+    "org.graylog2.outputs.LoggingOutput": {
+      "type": "org.graylog2.outputs.LoggingOutput",
+      "name": "STDOUT Output",
+      "human_name": "STDOUT Output",
+      "link_to_docs": "",
+      "requested_configuration": {
+        "prefix": {"type": "text", "default_value": "Writing message: "}
+      }
+    },
+    "org.graylog2.outputs.GelfOutput": {
+      "type": "org.graylog2.outputs.GelfOutput",
+      "name": "GELF Output",
+      "human_name": "GELF Output",
+      "link_to_docs": "",
+      "requested_configuration": {
+        "hostname": {"type": "text"}
+      }
+    }
+  }
+}`
+
+	getRoute := flute.Route{
+		Name:    "get available output types",
+		Matcher: flute.Matcher{Method: "GET", Path: "/api/system/outputs/available"},
+		Tester:  flute.Tester{PartOfHeader: testutil.Header()},
+		Response: flute.Response{Response: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+		}},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_output_types", DataSourceTypes()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getRoute) },
+				Config: `
+data "graylog_output_types" "all" {}
+`,
+				// the types are sorted by type
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_output_types.all", "types.#", "2"),
+					resource.TestCheckResourceAttr("data.graylog_output_types.all", "types.0.type", "org.graylog2.outputs.GelfOutput"),
+					resource.TestCheckResourceAttr("data.graylog_output_types.all", "types.1.name", "STDOUT Output"),
+					resource.TestCheckResourceAttr("data.graylog_output_types.all", "types.1.requested_configuration", `{"prefix":{"default_value":"Writing message: ","type":"text"}}`),
+				),
+			},
+		},
+	})
+}
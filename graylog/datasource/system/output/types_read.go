@@ -0,0 +1,53 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func readTypes(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+
+	body, _, err := cl.Output.GetAvailable(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the available output types: %w", err)
+	}
+	types, ok := body["types"].(map[string]interface{})
+	if !ok {
+		return errors.New("the response of Graylog API is unexpected. types isn't an object")
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	elems := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		t, _ := types[name].(map[string]interface{})
+		cfg, err := json.Marshal(t["requested_configuration"])
+		if err != nil {
+			return fmt.Errorf("failed to marshal the requested configuration of the output type %s as JSON: %w", name, err)
+		}
+		elems = append(elems, map[string]interface{}{
+			"type":                    name,
+			"name":                    t["name"],
+			"human_name":              t["human_name"],
+			"link_to_docs":            t["link_to_docs"],
+			"requested_configuration": string(cfg),
+		})
+	}
+	return list.Set(d, "types", elems)
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourcePipelines(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getList := flute.Route{
+		Name: "list pipelines",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/system/pipelines/pipeline",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `[
+  {
+    "id": "5ea3e4122ab79c001275832c",
+    "title": "prod-enrichment",
+    "source": "pipeline \"prod-enrichment\"\nend"
+  },
+  {
+    "id": "5ea3e4122ab79c001275832d",
+    "title": "dev-enrichment",
+    "source": "pipeline \"dev-enrichment\"\nend"
+  }
+]`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_pipelines", DataSourceList()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_pipelines" "prod" {
+  title_regex = "^prod-"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_pipelines.prod", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_pipelines.prod", "pipelines.0.pipeline_id", "5ea3e4122ab79c001275832c"),
+					resource.TestCheckResourceAttr("data.graylog_pipelines.prod", "pipelines.0.title", "prod-enrichment"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+)
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "title_regex")
+	if err != nil {
+		return err
+	}
+
+	pipelines, _, err := cl.Pipeline.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	rsc := DataSourceList().Schema["pipelines"].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, pipeline := range pipelines {
+		if !list.MatchRegex(re, pipeline["title"]) {
+			continue
+		}
+		pipeline["pipeline_id"] = pipeline["id"]
+		elem, err := list.Flatten(pipeline, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, "pipelines", elems)
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestDataSourceUsers(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	getList := flute.Route{
+		Name: "list users",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/users",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "users": [
+    {
+      "id": "local:admin",
+      "username": "admin",
+      "roles": ["Admin"],
+      "account_status": "enabled",
+      "read_only": true
+    },
+    {
+      "id": "5ea26bb42ab79c0012521287",
+      "username": "alice",
+      "roles": ["Reader", "Dashboard Creator"],
+      "account_status": "enabled",
+      "session_timeout_ms": 3600000
+    },
+    {
+      "id": "5ea26bb42ab79c0012521288",
+      "username": "bob",
+      "roles": ["Reader"],
+      "account_status": "disabled"
+    }
+  ]
+}`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleDataSourceProviders("graylog_users", DataSourceList()),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testutil.SetHTTPClient(t, getList) },
+				Config: `
+data "graylog_users" "readers" {
+  role = "Reader"
+}
+
+data "graylog_users" "enabled_readers" {
+  role     = "Reader"
+  disabled = false
+}
+
+data "graylog_users" "a" {
+  username_regex = "^a"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.graylog_users.readers", "users.#", "2"),
+					resource.TestCheckResourceAttr("data.graylog_users.readers", "users.0.username", "alice"),
+					resource.TestCheckResourceAttr("data.graylog_users.readers", "users.0.user_id", "5ea26bb42ab79c0012521287"),
+					resource.TestCheckResourceAttr("data.graylog_users.readers", "users.0.session_timeout_ms", "3600000"),
+					resource.TestCheckResourceAttr("data.graylog_users.enabled_readers", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.graylog_users.a", "users.#", "2"),
+				),
+			},
+		},
+	})
+}
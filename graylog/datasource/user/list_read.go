@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/list"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const accountStatusDisabled = "disabled"
+
+func readList(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	re, err := list.Regex(d, "username_regex")
+	if err != nil {
+		return err
+	}
+
+	body, _, err := cl.User.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	users, err := util.GetList(body, "users")
+	if err != nil {
+		return err
+	}
+
+	role, filterRole := d.GetOk("role")
+	disabled, filterDisabled := d.GetOkExists("disabled")
+	rsc := DataSourceList().Schema["users"].Elem.(*schema.Resource)
+	elems := []interface{}{}
+	for _, user := range users {
+		if !list.MatchRegex(re, user[keyUsername]) {
+			continue
+		}
+		if filterRole && !hasRole(user, role.(string)) {
+			continue
+		}
+		if filterDisabled && (user[keyAccountStatus] == accountStatusDisabled) != disabled.(bool) {
+			continue
+		}
+		user[keyUserID] = user[keyID]
+		elem, err := list.Flatten(user, rsc)
+		if err != nil {
+			return err
+		}
+		elems = append(elems, elem)
+	}
+	return list.Set(d, "users", elems)
+}
+
+func hasRole(user map[string]interface{}, role string) bool {
+	roles, _ := user[keyRoles].([]interface{})
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -3,14 +3,22 @@ package graylog
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/dashboard"
+	dashboardexport "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/dashboard/export"
 	dashboardwidget "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/dashboard/widget"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/role"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/search/saved"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/sidecar"
+	sidecarcollector "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/sidecar/collector"
+	sidecarconfiguration "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/sidecar/configuration"
+	sidecarstatus "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/sidecar/status"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/stream"
+	streammatch "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/stream/match"
 	streamrule "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/stream/rule"
 	dgrok "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/grok"
+	indexsetfields "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/indices/fields"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/indices/indexset"
+	fieldtypeprofile "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/indices/profile"
+	indexsetstats "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/indices/stats"
 	indextemplate "github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/indices/template"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/input"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/datasource/system/output"
@@ -20,21 +28,38 @@ import (
 )
 
 var dataSourcesMap = map[string]*schema.Resource{
-	"graylog_dashboard":           dashboard.DataSource(),
-	"graylog_dashboard_widget":    dashboardwidget.DataSource(),
-	"graylog_index_set":           indexset.DataSource(),
-	"graylog_input":               input.DataSource(),
-	"graylog_role":                role.DataSource(),
-	"graylog_sidecar":             sidecar.DataSource(),
-	"graylog_stream":              stream.DataSource(),
-	"graylog_stream_rule":         streamrule.DataSource(),
-	"graylog_pipeline":            ppipeline.DataSource(),
-	"graylog_pipeline_rule":       ppipelinerule.DataSource(),
-	"graylog_saved_search":        saved.DataSource(),
-	"graylog_grok_pattern":        dgrok.DataSource(),
-	"graylog_grok_patterns":       dgrok.DataSourceList(),
-	"graylog_output":              output.DataSource(),
-	"graylog_index_set_template":  indextemplate.DataSourceBuiltIn(),
-	"graylog_index_set_templates": indextemplate.DataSourceList(),
-	"graylog_user":                user.DataSource(),
+	"graylog_dashboard":                dashboard.DataSource(),
+	"graylog_dashboard_export":         dashboardexport.DataSource(),
+	"graylog_dashboard_widget":         dashboardwidget.DataSource(),
+	"graylog_index_field_type_profile": fieldtypeprofile.DataSource(),
+	"graylog_index_set":                indexset.DataSource(),
+	"graylog_index_sets":               indexset.DataSourceList(),
+	"graylog_index_set_fields":         indexsetfields.DataSource(),
+	"graylog_index_set_stats":          indexsetstats.DataSource(),
+	"graylog_input":                    input.DataSource(),
+	"graylog_inputs":                   input.DataSourceList(),
+	"graylog_role":                     role.DataSource(),
+	"graylog_roles":                    role.DataSourceList(),
+	"graylog_sidecar":                  sidecar.DataSource(),
+	"graylog_sidecars":                 sidecar.DataSourceList(),
+	"graylog_sidecar_collector":        sidecarcollector.DataSource(),
+	"graylog_sidecar_configuration":    sidecarconfiguration.DataSource(),
+	"graylog_sidecar_status":           sidecarstatus.DataSource(),
+	"graylog_stream":                   stream.DataSource(),
+	"graylog_streams":                  stream.DataSourceList(),
+	"graylog_stream_match_test":        streammatch.DataSource(),
+	"graylog_stream_rule":              streamrule.DataSource(),
+	"graylog_pipeline":                 ppipeline.DataSource(),
+	"graylog_pipelines":                ppipeline.DataSourceList(),
+	"graylog_pipeline_rule":            ppipelinerule.DataSource(),
+	"graylog_saved_search":             saved.DataSource(),
+	"graylog_grok_pattern":             dgrok.DataSource(),
+	"graylog_grok_patterns":            dgrok.DataSourceList(),
+	"graylog_output":                   output.DataSource(),
+	"graylog_outputs":                  output.DataSourceList(),
+	"graylog_output_types":             output.DataSourceTypes(),
+	"graylog_index_set_template":       indextemplate.DataSourceBuiltIn(),
+	"graylog_index_set_templates":      indextemplate.DataSourceList(),
+	"graylog_user":                     user.DataSource(),
+	"graylog_users":                    user.DataSourceList(),
 }
@@ -0,0 +1,238 @@
+// Package generate exports the configuration of a Graylog cluster to Terraform configuration files.
+// One file is written per resource type. Each resource is written with an import block,
+// and the IDs of the other generated resources are replaced with references to them.
+package generate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/hclgen"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Options are the options of Run.
+type Options struct {
+	// OutputDir is the directory where the files are written.
+	OutputDir string
+	// Types are the resource types to generate. If Types is empty, all supported resource types are generated.
+	Types []string
+}
+
+// resource is a resource read from Graylog.
+type resource struct {
+	item
+	resourceType string
+	values       map[string]interface{}
+}
+
+// Run reads the resources from Graylog and writes the configuration files to opts.OutputDir.
+// The provider p must be configured. Run returns the paths of the written files.
+func Run(ctx context.Context, p *schema.Provider, opts Options) ([]string, error) {
+	srcs, err := selectSources(opts.Types)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := client.New(p.Meta())
+	if err != nil {
+		return nil, err
+	}
+	var resources []resource
+	for _, src := range srcs {
+		rsc, ok := p.ResourcesMap[src.resourceType]
+		if !ok {
+			return nil, fmt.Errorf("resource type %s isn't supported by the provider", src.resourceType)
+		}
+		items, err := src.list(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range items {
+			var values map[string]interface{}
+			if src.export != nil {
+				values, err = src.export(ctx, cl, it.importID)
+			} else {
+				values, err = readResource(ctx, rsc, it.importID, p.Meta())
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s %s: %w", src.resourceType, it.importID, err)
+			}
+			if values == nil {
+				continue
+			}
+			resources = append(resources, resource{
+				item:         it,
+				resourceType: src.resourceType,
+				values:       values,
+			})
+		}
+	}
+
+	files := render(p.ResourcesMap, resources)
+	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil { //nolint:gosec
+		return nil, fmt.Errorf("failed to create the output directory %s: %w", opts.OutputDir, err)
+	}
+	paths := make([]string, 0, len(files))
+	for _, src := range srcs {
+		b, ok := files[src.resourceType]
+		if !ok {
+			continue
+		}
+		path := filepath.Join(opts.OutputDir, src.resourceType+".tf")
+		if err := os.WriteFile(path, b, 0o644); err != nil { //nolint:gosec
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func selectSources(types []string) ([]source, error) {
+	if len(types) == 0 {
+		return sources, nil
+	}
+	selected := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		selected[t] = struct{}{}
+	}
+	srcs := make([]source, 0, len(types))
+	for _, src := range sources {
+		if _, ok := selected[src.resourceType]; ok {
+			srcs = append(srcs, src)
+		}
+	}
+	if len(srcs) != len(selected) {
+		return nil, fmt.Errorf("unsupported resource types are included in %v. supported resource types: %v", types, ResourceTypes())
+	}
+	return srcs, nil
+}
+
+// readResource imports a resource by the import ID and reads it.
+// If the resource isn't found, nil is returned.
+func readResource(ctx context.Context, rsc *schema.Resource, id string, meta interface{}) (map[string]interface{}, error) {
+	d := rsc.Data(nil)
+	d.SetId(id)
+	if rsc.Importer != nil && rsc.Importer.StateContext != nil {
+		ds, err := rsc.Importer.StateContext(ctx, d, meta)
+		if err != nil {
+			return nil, err
+		}
+		if len(ds) != 1 {
+			return nil, fmt.Errorf("the importer returned %d resources", len(ds))
+		}
+		d = ds[0]
+	}
+	if rsc.ReadContext != nil {
+		if diags := rsc.ReadContext(ctx, d, meta); diags.HasError() {
+			return nil, fmt.Errorf("%s", diags[0].Summary)
+		}
+	} else if err := rsc.Read(d, meta); err != nil { //nolint:staticcheck
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, nil
+	}
+	values := make(map[string]interface{}, len(rsc.Schema))
+	for k := range rsc.Schema {
+		values[k] = d.Get(k)
+	}
+	return hclgen.Clean(rsc.Schema, values), nil
+}
+
+// render returns the content of the configuration file per resource type.
+// Resource names are generated from the titles and made unique per resource type,
+// then the values referring to the other resources are replaced with references.
+func render(schemas map[string]*schema.Resource, resources []resource) map[string][]byte {
+	names := make([]string, len(resources))
+	used := map[string]map[string]struct{}{}
+	refs := map[string]hclgen.Reference{}
+	scopedRefs := map[string]map[string]hclgen.Reference{}
+	for i, r := range resources {
+		if _, ok := used[r.resourceType]; !ok {
+			used[r.resourceType] = map[string]struct{}{}
+		}
+		names[i] = uniqueName(used[r.resourceType], hclgen.ResourceName(r.name))
+		if r.refKey == "" {
+			continue
+		}
+		ref := hclgen.Reference(r.resourceType + "." + names[i] + "." + r.refAttr)
+		if r.refScope == "" {
+			refs[r.refKey] = ref
+			continue
+		}
+		if _, ok := scopedRefs[r.refScope]; !ok {
+			scopedRefs[r.refScope] = map[string]hclgen.Reference{}
+		}
+		scopedRefs[r.refScope][r.refKey] = ref
+	}
+
+	files := map[string]*hclwrite.File{}
+	for i, r := range resources {
+		f, ok := files[r.resourceType]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[r.resourceType] = f
+		}
+		body := f.Body()
+		if len(body.Blocks()) != 0 {
+			body.AppendNewline()
+		}
+		address := r.resourceType + "." + names[i]
+
+		importBody := body.AppendNewBlock("import", nil).Body()
+		importBody.SetAttributeRaw("to", hclwrite.TokensForTraversal(hclgen.Reference(address).Traversal()))
+		importBody.SetAttributeValue("id", cty.StringVal(r.importID))
+		body.AppendNewline()
+
+		values := make(map[string]interface{}, len(r.values))
+		for k, v := range r.values {
+			if sr, ok := scopedRefs[k]; ok {
+				v = hclgen.ReplaceReferences(v, sr)
+			}
+			values[k] = hclgen.ReplaceReferences(v, withoutSelf(refs, address))
+		}
+		block := body.AppendNewBlock("resource", []string{r.resourceType, names[i]})
+		hclgen.WriteBody(block.Body(), schemas[r.resourceType].Schema, values)
+	}
+
+	ret := make(map[string][]byte, len(files))
+	for t, f := range files {
+		ret[t] = hclwrite.Format(f.Bytes())
+	}
+	return ret
+}
+
+// uniqueName returns name, or name with a numeric suffix if name is already used.
+func uniqueName(used map[string]struct{}, name string) string {
+	n := name
+	for i := 2; ; i++ {
+		if _, ok := used[n]; !ok {
+			used[n] = struct{}{}
+			return n
+		}
+		n = name + "_" + strconv.Itoa(i)
+	}
+}
+
+// withoutSelf returns refs without the references to the resource of the address,
+// because a resource can't refer to itself.
+func withoutSelf(refs map[string]hclgen.Reference, address string) map[string]hclgen.Reference {
+	for k, ref := range refs {
+		if ref.Address() == address {
+			m := make(map[string]hclgen.Reference, len(refs))
+			for k2, v := range refs {
+				if k2 != k {
+					m[k2] = v
+				}
+			}
+			return m
+		}
+	}
+	return refs
+}
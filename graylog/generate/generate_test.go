@@ -0,0 +1,302 @@
+package generate
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestRender(t *testing.T) {
+	schemas := graylog.Provider().ResourcesMap
+	resources := []resource{
+		{
+			item:         item{importID: "5f1", name: "App logs", refKey: "5f1", refAttr: "id"},
+			resourceType: "graylog_stream",
+			values:       map[string]interface{}{"title": "App logs", "index_set_id": "5f0"},
+		},
+		{
+			item:         item{importID: "5f2", name: "App logs", refKey: "5f2", refAttr: "id"},
+			resourceType: "graylog_stream",
+			values:       map[string]interface{}{"title": "App logs", "index_set_id": "5f0"},
+		},
+		{
+			item:         item{importID: "5f1/5f3", name: "App logs_source"},
+			resourceType: "graylog_stream_rule",
+			values:       map[string]interface{}{"stream_id": "5f1", "field": "source", "value": "5f2", "type": 1},
+		},
+		{
+			item:         item{importID: "Developers", name: "Developers", refKey: "Developers", refAttr: "name", refScope: "roles"},
+			resourceType: "graylog_role",
+			values:       map[string]interface{}{"name": "Developers", "permissions": []interface{}{"streams:read"}},
+		},
+		{
+			item:         item{importID: "alice", name: "alice"},
+			resourceType: "graylog_user",
+			values: map[string]interface{}{
+				"username":  "alice",
+				"full_name": "Developers",
+				"roles":     []interface{}{"Developers", "Reader"},
+			},
+		},
+	}
+	files := render(schemas, resources)
+
+	streams := string(files["graylog_stream"])
+	for _, exp := range []string{
+		"import {\n  to = graylog_stream.app_logs\n  id = \"5f1\"\n}",
+		`resource "graylog_stream" "app_logs" {`,
+		"import {\n  to = graylog_stream.app_logs_2\n  id = \"5f2\"\n}",
+		`resource "graylog_stream" "app_logs_2" {`,
+		`index_set_id = "5f0"`,
+	} {
+		if !strings.Contains(streams, exp) {
+			t.Fatalf("graylog_stream.tf doesn't contain %q:\n%s", exp, streams)
+		}
+	}
+
+	rules := string(files["graylog_stream_rule"])
+	for _, exp := range []string{
+		`id = "5f1/5f3"`,
+		"stream_id = graylog_stream.app_logs.id",
+		"value     = graylog_stream.app_logs_2.id",
+	} {
+		if !strings.Contains(rules, exp) {
+			t.Fatalf("graylog_stream_rule.tf doesn't contain %q:\n%s", exp, rules)
+		}
+	}
+
+	users := string(files["graylog_user"])
+	for _, exp := range []string{
+		`full_name = "Developers"`,
+		`roles     = [graylog_role.developers.name, "Reader"]`,
+	} {
+		if !strings.Contains(users, exp) {
+			t.Fatalf("graylog_user.tf doesn't contain %q:\n%s", exp, users)
+		}
+	}
+}
+
+func TestSelectSources(t *testing.T) {
+	srcs, err := selectSources(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srcs) != len(sources) {
+		t.Fatalf("all sources should be selected, got %d", len(srcs))
+	}
+	srcs, err = selectSources([]string{"graylog_user", "graylog_stream"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srcs) != 2 || srcs[0].resourceType != "graylog_stream" || srcs[1].resourceType != "graylog_user" {
+		t.Fatalf("sources should be selected in the dependency order: %v", srcs)
+	}
+	if _, err := selectSources([]string{"graylog_alarm_callback"}); err == nil {
+		t.Fatal("selectSources should return an error if the resource type isn't supported")
+	}
+}
+
+func TestSourcesAreSupportedByProvider(t *testing.T) {
+	resources := graylog.Provider().ResourcesMap
+	for _, src := range sources {
+		rsc, ok := resources[src.resourceType]
+		if !ok {
+			t.Fatalf("%s isn't a resource of the provider", src.resourceType)
+		}
+		if src.export == nil && rsc.Importer == nil {
+			t.Fatalf("%s doesn't support import", src.resourceType)
+		}
+	}
+}
+
+func getRoute(name, path, body string) flute.Route {
+	return flute.Route{
+		Name: name,
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   path,
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: body,
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.SetHTTPClient(t,
+		getRoute("list streams", "/api/streams", `{
+  "total": 2,
+  "streams": [
+    {
+      "id": "000000000000000000000001",
+      "title": "All messages",
+      "is_default": true
+    },
+    {
+      "id": "5ea26bb42ab79c0012521287",
+      "title": "App logs",
+      "is_editable": true,
+      "rules": [
+        {
+          "id": "5ea26bb42ab79c0012521289",
+          "field": "source"
+        }
+      ]
+    }
+  ]
+}`),
+		getRoute("get a stream", "/api/streams/5ea26bb42ab79c0012521287", `{
+  "id": "5ea26bb42ab79c0012521287",
+  "title": "App logs",
+  "index_set_id": "5e9861442ab79c0012e7d1c4",
+  "disabled": false,
+  "matching_type": "AND",
+  "remove_matches_from_default_stream": true
+}`),
+		getRoute("get a stream rule", "/api/streams/5ea26bb42ab79c0012521287/rules/5ea26bb42ab79c0012521289", `{
+  "id": "5ea26bb42ab79c0012521289",
+  "stream_id": "5ea26bb42ab79c0012521287",
+  "field": "source",
+  "value": "app",
+  "type": 1,
+  "inverted": false,
+  "description": ""
+}`),
+	)
+
+	ctx := context.Background()
+	p := graylog.Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		t.Fatalf("failed to configure the provider: %v", diags)
+	}
+
+	dir := t.TempDir()
+	paths, err := Run(ctx, p, Options{
+		OutputDir: dir,
+		Types:     []string{"graylog_stream", "graylog_stream_rule"},
+	})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	exp := []string{filepath.Join(dir, "graylog_stream.tf"), filepath.Join(dir, "graylog_stream_rule.tf")}
+	if strings.Join(paths, ",") != strings.Join(exp, ",") {
+		t.Fatalf("paths = %v, want %v", paths, exp)
+	}
+
+	b, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "All messages") {
+		t.Fatalf("the default stream shouldn't be generated:\n%s", b)
+	}
+	if !strings.Contains(string(b), `resource "graylog_stream" "app_logs" {`) {
+		t.Fatalf("graylog_stream.app_logs isn't generated:\n%s", b)
+	}
+	b, err = os.ReadFile(paths[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		`id = "5ea26bb42ab79c0012521287/5ea26bb42ab79c0012521289"`,
+		"stream_id = graylog_stream.app_logs.id",
+		`value     = "app"`,
+	} {
+		if !strings.Contains(string(b), s) {
+			t.Fatalf("graylog_stream_rule.tf doesn't contain %q:\n%s", s, b)
+		}
+	}
+}
+
+func TestListPipelineConnections(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Graylog returns 404 if no pipeline is connected to the stream
+	auditStatus := 404
+
+	testutil.SetHTTPClient(t,
+		getRoute("list streams", "/api/streams", `{
+  "total": 2,
+  "streams": [
+    {
+      "id": "5ea26bb42ab79c0012521287",
+      "title": "App logs"
+    },
+    {
+      "id": "5ea26bb42ab79c0012521288",
+      "title": "Audit logs"
+    }
+  ]
+}`),
+		getRoute("get pipeline connections of a stream", "/api/system/pipelines/connections/5ea26bb42ab79c0012521287", `{
+  "stream_id": "5ea26bb42ab79c0012521287",
+  "pipeline_ids": [
+    "5ea3e4122ab79c001275832c"
+  ]
+}`),
+		flute.Route{
+			Name: "get pipeline connections of a stream without connections",
+			Matcher: flute.Matcher{
+				Method: "GET",
+				Path:   "/api/system/pipelines/connections/5ea26bb42ab79c0012521288",
+			},
+			Tester: flute.Tester{
+				PartOfHeader: testutil.Header(),
+			},
+			Response: flute.Response{
+				Response: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: auditStatus,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"type": "ApiError", "message": "error"}`)),
+					}, nil
+				},
+			},
+		},
+	)
+
+	p := graylog.Provider()
+	ctx := context.Background()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		t.Fatalf("failed to configure the provider: %v", diags)
+	}
+	cl, err := client.New(p.Meta())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := listPipelineConnections(ctx, cl)
+	if err != nil {
+		t.Fatalf("listPipelineConnections returned error: %v", err)
+	}
+	if len(items) != 1 || items[0].importID != "5ea26bb42ab79c0012521287" {
+		t.Fatalf("items = %+v, want the connections of 5ea26bb42ab79c0012521287", items)
+	}
+
+	// the other errors aren't ignored
+	auditStatus = 500
+	if _, err := listPipelineConnections(ctx, cl); err == nil {
+		t.Fatal("listPipelineConnections should return an error if Graylog returns 500")
+	}
+}
@@ -0,0 +1,363 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/dashboard"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/event/definition"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/event/notification"
+	dashboardResource "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/dashboard"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const perPage = 100
+
+// item is a resource found in Graylog.
+type item struct {
+	// importID is the ID passed to the importer of the resource.
+	importID string
+	// name is converted to the resource name.
+	name string
+	// refKey is the value which other resources use to refer to this resource.
+	// If refKey is empty, the resource isn't referred.
+	refKey string
+	// refAttr is the attribute of this resource which is equal to refKey.
+	refAttr string
+	// refScope is the attribute of other resources where refKey is replaced with the reference.
+	// If refScope is empty, refKey is replaced in all attributes.
+	refScope string
+}
+
+// source lists the resources of a resource type.
+type source struct {
+	resourceType string
+	list         func(ctx context.Context, cl client.Client) ([]item, error)
+	// export returns the values of a resource. If export is nil, the resource is imported and read.
+	export func(ctx context.Context, cl client.Client, id string) (map[string]interface{}, error)
+}
+
+// sources are ordered so that the referred resources are generated first.
+var sources = []source{
+	{resourceType: "graylog_index_set", list: listIndexSets},
+	{resourceType: "graylog_stream", list: listStreams},
+	{resourceType: "graylog_stream_rule", list: listStreamRules},
+	{resourceType: "graylog_input", list: listInputs},
+	{resourceType: "graylog_extractor", list: listExtractors},
+	{resourceType: "graylog_pipeline_rule", list: listPipelineRules},
+	{resourceType: "graylog_pipeline", list: listPipelines},
+	{resourceType: "graylog_pipeline_connection", list: listPipelineConnections},
+	{resourceType: "graylog_event_notification", list: listEventNotifications},
+	{resourceType: "graylog_event_definition", list: listEventDefinitions},
+	{resourceType: "graylog_dashboard", list: listDashboards, export: dashboardResource.Export},
+	{resourceType: "graylog_role", list: listRoles},
+	{resourceType: "graylog_user", list: listUsers},
+}
+
+// ResourceTypes returns the resource types which can be generated.
+func ResourceTypes() []string {
+	types := make([]string, len(sources))
+	for i, src := range sources {
+		types[i] = src.resourceType
+	}
+	return types
+}
+
+func getString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+func getBool(m map[string]interface{}, key string) bool {
+	b, _ := m[key].(bool)
+	return b
+}
+
+// isDefaultScope returns false if the entity is managed by Graylog itself such as the system notification event definition.
+func isDefaultScope(m map[string]interface{}) bool {
+	scope := getString(m, "_scope")
+	return scope == "" || scope == "DEFAULT"
+}
+
+func listIndexSets(ctx context.Context, cl client.Client) ([]item, error) {
+	body, _, err := cl.IndexSet.Gets(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list index sets: %w", err)
+	}
+	list, err := util.GetList(body, "index_sets")
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, 0, len(list))
+	for _, a := range list {
+		if v, ok := a["can_be_default"].(bool); ok && !v {
+			// the index sets of events and system events
+			continue
+		}
+		id := getString(a, "id")
+		items = append(items, item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"})
+	}
+	return items, nil
+}
+
+func getStreams(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.Stream.Gets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list streams: %w", err)
+	}
+	list, err := util.GetList(body, "streams")
+	if err != nil {
+		return nil, err
+	}
+	streams := make([]map[string]interface{}, 0, len(list))
+	for _, a := range list {
+		// the default streams such as "All messages" can't be managed
+		if getBool(a, "is_default") {
+			continue
+		}
+		if v, ok := a["is_editable"].(bool); ok && !v {
+			continue
+		}
+		streams = append(streams, a)
+	}
+	return streams, nil
+}
+
+func listStreams(ctx context.Context, cl client.Client) ([]item, error) {
+	streams, err := getStreams(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, len(streams))
+	for i, a := range streams {
+		id := getString(a, "id")
+		items[i] = item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"}
+	}
+	return items, nil
+}
+
+func listStreamRules(ctx context.Context, cl client.Client) ([]item, error) {
+	streams, err := getStreams(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	var items []item
+	for _, stream := range streams {
+		rules, _ := stream["rules"].([]interface{})
+		for _, r := range rules {
+			rule, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			items = append(items, item{
+				importID: getString(stream, "id") + "/" + getString(rule, "id"),
+				name:     getString(stream, "title") + "_" + getString(rule, "field"),
+			})
+		}
+	}
+	return items, nil
+}
+
+func getInputs(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.Input.Gets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inputs: %w", err)
+	}
+	return util.GetList(body, "inputs")
+}
+
+func listInputs(ctx context.Context, cl client.Client) ([]item, error) {
+	inputs, err := getInputs(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, len(inputs))
+	for i, a := range inputs {
+		id := getString(a, "id")
+		items[i] = item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"}
+	}
+	return items, nil
+}
+
+func listExtractors(ctx context.Context, cl client.Client) ([]item, error) {
+	inputs, err := getInputs(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	var items []item
+	for _, input := range inputs {
+		inputID := getString(input, "id")
+		body, _, err := cl.Extractor.Gets(ctx, inputID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list extractors of the input %s: %w", inputID, err)
+		}
+		extractors, err := util.GetList(body, "extractors")
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range extractors {
+			items = append(items, item{
+				importID: inputID + "/" + getString(a, "id"),
+				name:     getString(input, "title") + "_" + getString(a, "title"),
+			})
+		}
+	}
+	return items, nil
+}
+
+func listPipelineRules(ctx context.Context, cl client.Client) ([]item, error) {
+	rules, _, err := cl.PipelineRule.Gets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline rules: %w", err)
+	}
+	items := make([]item, len(rules))
+	for i, a := range rules {
+		id := getString(a, "id")
+		items[i] = item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"}
+	}
+	return items, nil
+}
+
+func listPipelines(ctx context.Context, cl client.Client) ([]item, error) {
+	pipelines, _, err := cl.Pipeline.Gets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+	items := make([]item, len(pipelines))
+	for i, a := range pipelines {
+		id := getString(a, "id")
+		items[i] = item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"}
+	}
+	return items, nil
+}
+
+func listPipelineConnections(ctx context.Context, cl client.Client) ([]item, error) {
+	streams, err := getStreams(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	var items []item
+	for _, stream := range streams {
+		id := getString(stream, "id")
+		body, resp, err := cl.PipelineConnection.GetConnectionsOfStream(ctx, id)
+		if err != nil {
+			// Graylog returns 404 if no pipeline is connected to the stream
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get pipeline connections of the stream %s: %w", id, err)
+		}
+		if ids, _ := body["pipeline_ids"].([]interface{}); len(ids) == 0 {
+			continue
+		}
+		items = append(items, item{importID: id, name: getString(stream, "title")})
+	}
+	return items, nil
+}
+
+func listEventNotifications(ctx context.Context, cl client.Client) ([]item, error) {
+	list, err := util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.EventNotification.Gets(ctx, &notification.GetAllParams{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list event notifications: %w", err)
+		}
+		return body, nil
+	}, "notifications")
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, 0, len(list))
+	for _, a := range list {
+		if !isDefaultScope(a) {
+			continue
+		}
+		id := getString(a, "id")
+		items = append(items, item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"})
+	}
+	return items, nil
+}
+
+func listEventDefinitions(ctx context.Context, cl client.Client) ([]item, error) {
+	list, err := util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.EventDefinition.Gets(ctx, &definition.GetAllParams{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list event definitions: %w", err)
+		}
+		return body, nil
+	}, "event_definitions")
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, 0, len(list))
+	for _, a := range list {
+		if !isDefaultScope(a) {
+			continue
+		}
+		id := getString(a, "id")
+		items = append(items, item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"})
+	}
+	return items, nil
+}
+
+func listDashboards(ctx context.Context, cl client.Client) ([]item, error) {
+	list, err := util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.Dashboard.Gets(ctx, &dashboard.GetAllParams{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list dashboards: %w", err)
+		}
+		return body, nil
+	}, "elements")
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, len(list))
+	for i, a := range list {
+		id := getString(a, "id")
+		items[i] = item{importID: id, name: getString(a, "title"), refKey: id, refAttr: "id"}
+	}
+	return items, nil
+}
+
+func listRoles(ctx context.Context, cl client.Client) ([]item, error) {
+	roles, _, err := cl.Role.Gets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	items := make([]item, 0, len(roles))
+	for _, a := range roles {
+		// the built-in roles such as "Admin" are read only
+		if getBool(a, "read_only") {
+			continue
+		}
+		name := getString(a, "name")
+		items = append(items, item{importID: name, name: name, refKey: name, refAttr: "name", refScope: "roles"})
+	}
+	return items, nil
+}
+
+func listUsers(ctx context.Context, cl client.Client) ([]item, error) {
+	body, _, err := cl.User.Gets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	users, err := util.GetList(body, "users")
+	if err != nil {
+		return nil, err
+	}
+	items := make([]item, 0, len(users))
+	for _, a := range users {
+		// the admin user and the users synchronized from LDAP aren't managed by Terraform
+		if getBool(a, "read_only") || getBool(a, "external") {
+			continue
+		}
+		username := getString(a, "username")
+		if username == "" {
+			return nil, errors.New("the response of Graylog API is unexpected. username is empty")
+		}
+		items = append(items, item{importID: username, name: username})
+	}
+	return items, nil
+}
@@ -0,0 +1,323 @@
+// Package hclgen renders resource data to Terraform configuration in the HCL and JSON syntax.
+package hclgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Clean removes the attributes which don't have to be written to the configuration from values.
+// Computed only attributes, attributes whose value is the default value and empty optional attributes are removed.
+// Sets are converted to lists.
+func Clean(sch map[string]*schema.Schema, values map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		s, ok := sch[k]
+		if !ok || (s.Computed && !s.Optional && !s.Required) {
+			continue
+		}
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+		}
+		if rsc, ok := s.Elem.(*schema.Resource); ok {
+			list, _ := v.([]interface{})
+			blocks := make([]interface{}, 0, len(list))
+			for _, a := range list {
+				m, ok := a.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				blocks = append(blocks, Clean(rsc.Schema, m))
+			}
+			if len(blocks) == 0 {
+				continue
+			}
+			ret[k] = blocks
+			continue
+		}
+		if s.Required {
+			ret[k] = v
+			continue
+		}
+		if s.Default != nil && fmt.Sprint(s.Default) == fmt.Sprint(v) {
+			continue
+		}
+		if isZero(v) {
+			continue
+		}
+		ret[k] = v
+	}
+	return ret
+}
+
+func isZero(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case int:
+		return t == 0
+	case float64:
+		return t == 0
+	case bool:
+		return !t
+	case []interface{}:
+		return len(t) == 0
+	case map[string]interface{}:
+		return len(t) == 0
+	}
+	return false
+}
+
+// WriteBody writes cleaned values to body. Nested resources are written as blocks and
+// JSON string attributes are written with jsonencode so that they are readable.
+func WriteBody(body *hclwrite.Body, sch map[string]*schema.Schema, values map[string]interface{}) {
+	// attributes first, then blocks, in the alphabetical order for stable output
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, ok := sch[k].Elem.(*schema.Resource); ok {
+			continue
+		}
+		writeAttribute(body, k, values[k])
+	}
+	for _, k := range keys {
+		rsc, ok := sch[k].Elem.(*schema.Resource)
+		if !ok {
+			continue
+		}
+		list, _ := values[k].([]interface{})
+		for _, a := range list {
+			body.AppendNewline()
+			block := body.AppendNewBlock(k, nil)
+			WriteBody(block.Body(), rsc.Schema, a.(map[string]interface{}))
+		}
+	}
+}
+
+func writeAttribute(body *hclwrite.Body, k string, v interface{}) {
+	if s, ok := v.(string); ok {
+		if j, ok := parseJSON(s); ok {
+			v = JSONEncode{Value: j}
+		}
+	}
+	body.SetAttributeRaw(k, tokens(v))
+}
+
+// Reference is an expression which refers to an attribute of another resource such as graylog_stream.foo.id.
+type Reference string
+
+// Address returns the address of the referred resource.
+func (r Reference) Address() string {
+	parts := strings.SplitN(string(r), ".", 3)
+	if len(parts) < 2 {
+		return string(r)
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// Traversal returns the traversal of the reference.
+func (r Reference) Traversal() hcl.Traversal {
+	parts := strings.Split(string(r), ".")
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}}
+	for _, p := range parts[1:] {
+		traversal = append(traversal, hcl.TraverseAttr{Name: p})
+	}
+	return traversal
+}
+
+// JSONEncode is a value which is written with the jsonencode function.
+type JSONEncode struct {
+	Value interface{}
+}
+
+// tokens returns the tokens of a value which may contain references.
+func tokens(v interface{}) hclwrite.Tokens {
+	switch t := v.(type) {
+	case Reference:
+		return hclwrite.TokensForTraversal(t.Traversal())
+	case JSONEncode:
+		return hclwrite.TokensForFunctionCall("jsonencode", tokens(t.Value))
+	case []interface{}:
+		if !hasExpression(t) {
+			break
+		}
+		elems := make([]hclwrite.Tokens, len(t))
+		for i, a := range t {
+			elems[i] = tokens(a)
+		}
+		return hclwrite.TokensForTuple(elems)
+	case map[string]interface{}:
+		if !hasExpression(t) {
+			break
+		}
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		attrs := make([]hclwrite.ObjectAttrTokens, len(keys))
+		for i, k := range keys {
+			name := hclwrite.TokensForValue(cty.StringVal(k))
+			if hclsyntax.ValidIdentifier(k) {
+				name = hclwrite.TokensForIdentifier(k)
+			}
+			attrs[i] = hclwrite.ObjectAttrTokens{Name: name, Value: tokens(t[k])}
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	return hclwrite.TokensForValue(ToCty(v))
+}
+
+// hasExpression returns true if v contains a Reference or JSONEncode.
+func hasExpression(v interface{}) bool {
+	switch t := v.(type) {
+	case Reference, JSONEncode:
+		return true
+	case []interface{}:
+		for _, a := range t {
+			if hasExpression(a) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, a := range t {
+			if hasExpression(a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReplaceReferences replaces the strings which are equal to a key of refs with the reference.
+// The strings in JSON string attributes are replaced too, then the attribute is converted to JSONEncode.
+func ReplaceReferences(v interface{}, refs map[string]Reference) interface{} {
+	switch t := v.(type) {
+	case string:
+		if ref, ok := refs[t]; ok {
+			return ref
+		}
+		if j, ok := parseJSON(t); ok {
+			if r := ReplaceReferences(j, refs); hasExpression(r) {
+				return JSONEncode{Value: r}
+			}
+		}
+	case []interface{}:
+		list := make([]interface{}, len(t))
+		for i, a := range t {
+			list[i] = ReplaceReferences(a, refs)
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, a := range t {
+			m[k] = ReplaceReferences(a, refs)
+		}
+		return m
+	}
+	return v
+}
+
+// parseJSON parses s if it's a JSON object or array.
+func parseJSON(s string) (interface{}, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+		return nil, false
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// ToCty converts a value of resource data or decoded JSON to cty.Value.
+func ToCty(v interface{}) cty.Value {
+	switch t := v.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType)
+	case string:
+		return cty.StringVal(t)
+	case bool:
+		return cty.BoolVal(t)
+	case int:
+		return cty.NumberIntVal(int64(t))
+	case float64:
+		return cty.NumberVal(new(big.Float).SetFloat64(t))
+	case *schema.Set:
+		return ToCty(t.List())
+	case []string:
+		vals := make([]interface{}, len(t))
+		for i, s := range t {
+			vals[i] = s
+		}
+		return ToCty(vals)
+	case []interface{}:
+		if len(t) == 0 {
+			return cty.EmptyTupleVal
+		}
+		vals := make([]cty.Value, len(t))
+		for i, a := range t {
+			vals[i] = ToCty(a)
+		}
+		return cty.TupleVal(vals)
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return cty.EmptyObjectVal
+		}
+		vals := make(map[string]cty.Value, len(t))
+		for k, a := range t {
+			vals[k] = ToCty(a)
+		}
+		return cty.ObjectVal(vals)
+	}
+	return cty.StringVal(fmt.Sprint(v))
+}
+
+// Resource returns the HCL of a resource block.
+func Resource(resourceType, name string, rsc *schema.Resource, values map[string]interface{}) []byte {
+	f := hclwrite.NewEmptyFile()
+	block := f.Body().AppendNewBlock("resource", []string{resourceType, name})
+	WriteBody(block.Body(), rsc.Schema, values)
+	return hclwrite.Format(f.Bytes())
+}
+
+// ResourceJSON returns the configuration of a resource in the Terraform JSON syntax.
+func ResourceJSON(resourceType, name string, values map[string]interface{}) ([]byte, error) {
+	return json.MarshalIndent(map[string]interface{}{
+		"resource": map[string]interface{}{
+			resourceType: map[string]interface{}{
+				name: values,
+			},
+		},
+	}, "", "  ")
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// ResourceName converts s such as a title to a valid resource name.
+func ResourceName(s string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(s), "_"), "_-")
+	if name == "" {
+		return "this"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,88 @@
+package hclgen
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceName(t *testing.T) {
+	data := map[string]string{
+		"Network Overview":  "network_overview",
+		"  app-logs (prod)": "app-logs_prod",
+		"2024 errors":       "_2024_errors",
+		"!!!":               "this",
+	}
+	for s, exp := range data {
+		if name := ResourceName(s); name != exp {
+			t.Fatalf("ResourceName(%q) = %q, want %q", s, name, exp)
+		}
+	}
+}
+
+func TestClean(t *testing.T) {
+	sch := map[string]*schema.Schema{
+		"title":    {Type: schema.TypeString, Required: true},
+		"disabled": {Type: schema.TypeBool, Optional: true},
+		"type":     {Type: schema.TypeString, Optional: true, Default: "values"},
+		"limit":    {Type: schema.TypeInt, Optional: true, Default: 15},
+		"id":       {Type: schema.TypeString, Computed: true},
+		"block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"field": {Type: schema.TypeString, Optional: true},
+				},
+			},
+		},
+	}
+	values := Clean(sch, map[string]interface{}{
+		"title":    "",
+		"disabled": false,
+		"type":     "values",
+		"limit":    10,
+		"id":       "id",
+		"block":    []interface{}{map[string]interface{}{"field": ""}},
+	})
+	exp := map[string]interface{}{
+		"title": "",
+		"limit": 10,
+		"block": []interface{}{map[string]interface{}{}},
+	}
+	if !reflect.DeepEqual(values, exp) {
+		t.Fatalf("Clean = %v, want %v", values, exp)
+	}
+}
+
+func TestReplaceReferences(t *testing.T) {
+	sch := map[string]*schema.Schema{
+		"stream_id":    {Type: schema.TypeString, Required: true},
+		"pipeline_ids": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"config":       {Type: schema.TypeString, Optional: true},
+		"title":        {Type: schema.TypeString, Optional: true},
+	}
+	refs := map[string]Reference{
+		"5f1": "graylog_stream.app.id",
+		"5f2": "graylog_pipeline.parse.id",
+	}
+	values := ReplaceReferences(map[string]interface{}{
+		"stream_id":    "5f1",
+		"pipeline_ids": []interface{}{"5f2", "5f3"},
+		"config":       `{"streams":["5f1"],"query":""}`,
+		"title":        "5f1 logs",
+	}, refs).(map[string]interface{})
+	b := Resource("graylog_example", "test", &schema.Resource{Schema: sch}, values)
+	for _, exp := range []string{
+		"stream_id    = graylog_stream.app.id",
+		`pipeline_ids = [graylog_pipeline.parse.id, "5f3"]`,
+		"streams = [graylog_stream.app.id]",
+		`title        = "5f1 logs"`,
+	} {
+		if !strings.Contains(string(b), exp) {
+			t.Fatalf("generated HCL doesn't contain %q:\n%s", exp, b)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package importer provides the functions to import resources by natural keys such as a stream title.
+// Natural keys are resolved to IDs with the list endpoints of Graylog API. Raw IDs are accepted too.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+)
+
+// Resolver resolves the key of a resource to the ID.
+type Resolver func(ctx context.Context, cl client.Client, key string) (string, error)
+
+// ChildResolver resolves the key of a resource which belongs to the parent resource to the ID.
+type ChildResolver func(ctx context.Context, cl client.Client, parentID, key string) (string, error)
+
+var objectIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+// IsObjectID returns true if s is a MongoDB ObjectID, which Graylog uses as the ID of most of entities.
+func IsObjectID(s string) bool {
+	return objectIDPattern.MatchString(s)
+}
+
+// StateFunc returns the StateContextFunc which resolves the import ID with resolve.
+func StateFunc(resolve Resolver) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		cl, err := client.New(m)
+		if err != nil {
+			return nil, err
+		}
+		id, err := resolve(ctx, cl, d.Id())
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// Part is a part of the composite import ID such as "stream_id/rule_id".
+type Part struct {
+	// Key is the attribute which the resolved ID is set to.
+	Key string
+	// Resolve resolves the part. parentID is the resolved ID of the preceding part.
+	// If Resolve is nil, the part is used as is.
+	Resolve ChildResolver
+}
+
+// ID returns the part which is used as is.
+func ID(key string) Part {
+	return Part{Key: key}
+}
+
+// Parent returns the part which is resolved with resolve.
+func Parent(key string, resolve Resolver) Part {
+	return Part{
+		Key: key,
+		Resolve: func(ctx context.Context, cl client.Client, _, k string) (string, error) {
+			return resolve(ctx, cl, k)
+		},
+	}
+}
+
+// Child returns the part which is resolved with resolve in the scope of the preceding part.
+func Child(key string, resolve ChildResolver) Part {
+	return Part{Key: key, Resolve: resolve}
+}
+
+// SplitID splits the import ID by "/" into n parts at most.
+// The last part may contain "/". The other parts can contain "/" only in quoted values such as title:"a/b".
+func SplitID(id string, n int) []string {
+	var a []string
+	quoted, escaped, start := false, false, 0
+	for i := 0; i < len(id) && len(a) < n-1; i++ {
+		switch c := id[i]; {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == '/':
+			a = append(a, id[start:i])
+			start = i + 1
+		}
+	}
+	return append(a, id[start:])
+}
+
+// GenStateFunc is like util.GenStateFunc, but each part of the import ID is resolved.
+// The last part may contain "/". The other parts have to be quoted with a selector if they contain "/".
+func GenStateFunc(parts ...Part) schema.StateContextFunc {
+	keys := make([]string, len(parts))
+	for i, p := range parts {
+		keys[i] = p.Key
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		a := SplitID(d.Id(), len(parts))
+		if len(a) != len(parts) {
+			return nil, errors.New("format of import argument should be " + strings.Join(keys, "/"))
+		}
+		cl, err := client.New(m)
+		if err != nil {
+			return nil, err
+		}
+		parentID := ""
+		for i, p := range parts {
+			id := a[i]
+			if p.Resolve != nil {
+				id, err = p.Resolve(ctx, cl, parentID, id)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if err := d.Set(p.Key, id); err != nil {
+				return nil, err
+			}
+			a[i] = id
+			parentID = id
+		}
+		d.SetId(strings.Join(a, "/"))
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// lister lists the entities of a resource type.
+type lister func(ctx context.Context, cl client.Client) ([]map[string]interface{}, error)
+
+// selector is the prefix of the import key such as "prefix" of "prefix:graylog_app",
+// which selects the attribute used to look up the entity.
+type selector struct {
+	name string
+	attr string
+}
+
+func by(name, attr string) selector {
+	return selector{name: name, attr: attr}
+}
+
+// selectorID is the selector "id:", which can be used for all resources.
+var selectorID = by("id", "id")
+
+// parseKey parses the import key in the format "<selector>:<value>" or "<value>".
+// The value may be quoted such as title:"Nginx access".
+// If the key has no known selector, the first selector is used for the whole key,
+// because titles may contain ":". explicit is true if the selector is given.
+func parseKey(key string, selectors []selector) (sel selector, value string, explicit bool) {
+	if i := strings.Index(key, ":"); i > 0 {
+		name := key[:i]
+		for _, s := range append([]selector{selectorID}, selectors...) {
+			if s.name == name {
+				return s, unquote(key[i+1:]), true
+			}
+		}
+	}
+	return selectors[0], key, false
+}
+
+func unquote(s string) string {
+	if len(s) < 2 || !strings.HasPrefix(s, `"`) || !strings.HasSuffix(s, `"`) {
+		return s
+	}
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return s
+}
+
+// resolver returns the Resolver which finds the entity whose attribute selected by the key equals to the value.
+// The first selector is used if the key has no selector. A key without a selector is used as is if it's an ObjectID.
+func resolver(kind string, list lister, selectors ...selector) Resolver {
+	return func(ctx context.Context, cl client.Client, key string) (string, error) {
+		sel, value, explicit := parseKey(key, selectors)
+		if sel == selectorID || (!explicit && IsObjectID(value)) {
+			return value, nil
+		}
+		entities, err := list(ctx, cl)
+		if err != nil {
+			return "", fmt.Errorf("failed to list %ss to import %q: %w", kind, key, err)
+		}
+		return find(kind, sel.attr, value, entities)
+	}
+}
+
+// find returns the ID of the entity whose attribute attr equals to value.
+// An error is returned if no entity or multiple entities are found.
+func find(kind, attr, value string, entities []map[string]interface{}) (string, error) {
+	var ids []string
+	for _, e := range entities {
+		if v, _ := e[attr].(string); v == value {
+			id, _ := e["id"].(string)
+			ids = append(ids, id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s is found with %s %q", kind, attr, value)
+	case 1:
+		return ids[0], nil
+	}
+	return "", fmt.Errorf("%d %ss are found with %s %q, import it by the ID instead: %s",
+		len(ids), kind, attr, value, strings.Join(ids, ", "))
+}
@@ -0,0 +1,243 @@
+package importer
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/config"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+const (
+	inputID     = "5f1e3c0b2ab79c0012345601"
+	extractorID = "5f1e3c0b2ab79c0012345602"
+)
+
+var cfg = config.Config{
+	Endpoint:     "http://example.com/api",
+	AuthName:     "admin",
+	AuthPassword: "admin",
+}
+
+func getRoute(name, path, body string) flute.Route {
+	return flute.Route{
+		Name: name,
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   path,
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: body,
+		},
+	}
+}
+
+func TestIsObjectID(t *testing.T) {
+	if !IsObjectID(inputID) {
+		t.Fatalf("%s should be an ObjectID", inputID)
+	}
+	for _, s := range []string{"", "graylog_app", "5f1e3c0b2ab79c001234560", "5f1e3c0b2ab79c0012345601/x"} {
+		if IsObjectID(s) {
+			t.Fatalf("%q shouldn't be an ObjectID", s)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	entities := []map[string]interface{}{
+		{"id": "a", "title": "GELF UDP"},
+		{"id": "b", "title": "Syslog"},
+		{"id": "c", "title": "Syslog"},
+	}
+	id, err := find("input", "title", "GELF UDP", entities)
+	if err != nil || id != "a" {
+		t.Fatalf("find = %q, %v, want a", id, err)
+	}
+	if _, err := find("input", "title", "Syslog", entities); err == nil || !strings.Contains(err.Error(), "2 inputs are found") {
+		t.Fatalf("find should return an error if the title is ambiguous: %v", err)
+	}
+	if _, err := find("input", "title", "Beats", entities); err == nil || !strings.Contains(err.Error(), "no input is found") {
+		t.Fatalf("find should return an error if no input is found: %v", err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	selectors := []selector{by("prefix", "index_prefix"), by("title", "title")}
+	data := []struct {
+		key      string
+		attr     string
+		value    string
+		explicit bool
+	}{
+		{key: "graylog_app", attr: "index_prefix", value: "graylog_app"},
+		{key: "prefix:graylog_app", attr: "index_prefix", value: "graylog_app", explicit: true},
+		{key: `title:"App logs"`, attr: "title", value: "App logs", explicit: true},
+		{key: "title:App logs", attr: "title", value: "App logs", explicit: true},
+		{key: "id:5f1e3c0b2ab79c0012345600", attr: "id", value: "5f1e3c0b2ab79c0012345600", explicit: true},
+		{key: "nginx:access", attr: "index_prefix", value: "nginx:access"},
+	}
+	for _, d := range data {
+		sel, value, explicit := parseKey(d.key, selectors)
+		if sel.attr != d.attr || value != d.value || explicit != d.explicit {
+			t.Fatalf("parseKey(%q) = %s, %q, %v, want %s, %q, %v", d.key, sel.attr, value, explicit, d.attr, d.value, d.explicit)
+		}
+	}
+}
+
+func TestStateFunc(t *testing.T) {
+	testutil.SetHTTPClient(t, getRoute("list index sets", "/api/system/indices/index_sets", `{
+  "total": 3,
+  "index_sets": [
+    {
+      "id": "5f1e3c0b2ab79c0012345600",
+      "title": "App",
+      "index_prefix": "graylog_app"
+    },
+    {
+      "id": "5f1e3c0b2ab79c0012345609",
+      "title": "Shared",
+      "index_prefix": "shared_a"
+    },
+    {
+      "id": "5f1e3c0b2ab79c0012345608",
+      "title": "Shared",
+      "index_prefix": "shared_b"
+    }
+  ]
+}`))
+	rsc := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	for _, key := range []string{"graylog_app", "prefix:graylog_app", `title:"App"`, "5f1e3c0b2ab79c0012345600"} {
+		d := rsc.Data(nil)
+		d.SetId(key)
+		ds, err := StateFunc(IndexSet)(context.Background(), d, cfg)
+		if err != nil {
+			t.Fatalf("failed to import %s: %v", key, err)
+		}
+		if id := ds[0].Id(); id != "5f1e3c0b2ab79c0012345600" {
+			t.Fatalf("id = %s, want 5f1e3c0b2ab79c0012345600", id)
+		}
+	}
+}
+
+func TestStateFuncAmbiguous(t *testing.T) {
+	testutil.SetHTTPClient(t, getRoute("list index sets", "/api/system/indices/index_sets", `{
+  "total": 2,
+  "index_sets": [
+    {
+      "id": "5f1e3c0b2ab79c0012345609",
+      "title": "Shared",
+      "index_prefix": "shared_a"
+    },
+    {
+      "id": "5f1e3c0b2ab79c0012345608",
+      "title": "Shared",
+      "index_prefix": "shared_b"
+    }
+  ]
+}`))
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).Data(nil)
+	d.SetId("title:Shared")
+	_, err := StateFunc(IndexSet)(context.Background(), d, cfg)
+	if err == nil || !strings.Contains(err.Error(), "2 index sets are found with title") {
+		t.Fatalf("StateFunc should return an error if the title is ambiguous: %v", err)
+	}
+}
+
+func TestUser(t *testing.T) {
+	testutil.SetHTTPClient(t, getRoute("get a user by id", "/api/users/id/5f1e3c0b2ab79c0012345607", `{
+  "id": "5f1e3c0b2ab79c0012345607",
+  "username": "alice"
+}`))
+	cl, err := client.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"alice", "username:alice", "5f1e3c0b2ab79c0012345607", "id:5f1e3c0b2ab79c0012345607"} {
+		username, err := User(context.Background(), cl, key)
+		if err != nil {
+			t.Fatalf("failed to resolve %s: %v", key, err)
+		}
+		if username != "alice" {
+			t.Fatalf("User(%q) = %q, want alice", key, username)
+		}
+	}
+}
+
+func TestSplitID(t *testing.T) {
+	data := []struct {
+		id  string
+		n   int
+		exp []string
+	}{
+		{"a/b/c", 2, []string{"a", "b/c"}},
+		{"a/b/c", 3, []string{"a", "b", "c"}},
+		{"a", 2, []string{"a"}},
+		{`title:"a/b"/c/d`, 2, []string{`title:"a/b"`, "c/d"}},
+		{`title:"a\"/b"/c`, 2, []string{`title:"a\"/b"`, "c"}},
+	}
+	for _, d := range data {
+		if a := SplitID(d.id, d.n); !reflect.DeepEqual(a, d.exp) {
+			t.Fatalf("SplitID(%q, %d) = %q, want %q", d.id, d.n, a, d.exp)
+		}
+	}
+}
+
+func TestGenStateFunc(t *testing.T) {
+	testutil.SetHTTPClient(t,
+		getRoute("list inputs", "/api/system/inputs", `{
+  "total": 1,
+  "inputs": [
+    {
+      "id": "`+inputID+`",
+      "title": "GELF UDP"
+    }
+  ]
+}`),
+		getRoute("list extractors", "/api/system/inputs/"+inputID+"/extractors", `{
+  "total": 1,
+  "extractors": [
+    {
+      "id": "`+extractorID+`",
+      "title": "split/message"
+    }
+  ]
+}`),
+	)
+	rsc := &schema.Resource{Schema: map[string]*schema.Schema{
+		"input_id":     {Type: schema.TypeString, Required: true},
+		"extractor_id": {Type: schema.TypeString, Computed: true},
+	}}
+	f := GenStateFunc(Parent("input_id", Input), Child("extractor_id", Extractor))
+	for _, key := range []string{"GELF UDP/split/message", `title:"GELF UDP"/split/message`, inputID + "/split/message", inputID + "/" + extractorID} {
+		d := rsc.Data(nil)
+		d.SetId(key)
+		ds, err := f(context.Background(), d, cfg)
+		if err != nil {
+			t.Fatalf("failed to import %s: %v", key, err)
+		}
+		if id := ds[0].Id(); id != inputID+"/"+extractorID {
+			t.Fatalf("id = %s, want %s/%s", id, inputID, extractorID)
+		}
+		if v := ds[0].Get("extractor_id"); v != extractorID {
+			t.Fatalf("extractor_id = %v, want %s", v, extractorID)
+		}
+	}
+
+	d := rsc.Data(nil)
+	d.SetId(inputID)
+	if _, err := f(context.Background(), d, cfg); err == nil {
+		t.Fatal("GenStateFunc should return an error if the format of the import ID is invalid")
+	}
+}
@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/dashboard"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/event/definition"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/event/notification"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/sidecar/configuration"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/profile"
+	indextemplate "github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/template"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/view"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const perPage = 100
+
+var (
+	// Stream resolves a stream title.
+	Stream = resolver("stream", listStreams, by("title", "title"))
+	// IndexSet resolves an index prefix, or the title with the selector "title:".
+	IndexSet = resolver("index set", listIndexSets, by("prefix", "index_prefix"), by("title", "title"))
+	// IndexSetTemplate resolves an index set template title.
+	IndexSetTemplate = resolver("index set template", listIndexSetTemplates, by("title", "title"))
+	// IndexFieldTypeProfile resolves an index field type profile name.
+	IndexFieldTypeProfile = resolver("index field type profile", listIndexFieldTypeProfiles, by("name", "name"))
+	// Input resolves an input title.
+	Input = resolver("input", listInputs, by("title", "title"))
+	// Output resolves an output title.
+	Output = resolver("output", listOutputs, by("title", "title"))
+	// Pipeline resolves a pipeline title.
+	Pipeline = resolver("pipeline", listPipelines, by("title", "title"))
+	// PipelineRule resolves a pipeline rule title.
+	PipelineRule = resolver("pipeline rule", listPipelineRules, by("title", "title"))
+	// EventDefinition resolves an event definition title.
+	EventDefinition = resolver("event definition", listEventDefinitions, by("title", "title"))
+	// EventNotification resolves an event notification title.
+	EventNotification = resolver("event notification", listEventNotifications, by("title", "title"))
+	// Dashboard resolves a dashboard title.
+	Dashboard = resolver("dashboard", listDashboards, by("title", "title"))
+	// View resolves a view title.
+	View = resolver("view", listViews, by("title", "title"))
+	// SavedSearch resolves a saved search title.
+	SavedSearch = resolver("saved search", listSavedSearches, by("title", "title"))
+	// GrokPattern resolves a grok pattern name.
+	GrokPattern = resolver("grok pattern", listGrokPatterns, by("name", "name"))
+	// SidecarCollector resolves a sidecar collector name.
+	SidecarCollector = resolver("sidecar collector", listSidecarCollectors, by("name", "name"))
+	// SidecarConfiguration resolves a sidecar configuration name.
+	SidecarConfiguration = resolver("sidecar configuration", listSidecarConfigurations, by("name", "name"))
+	// SidecarConfigurationVariable resolves a sidecar configuration variable name.
+	SidecarConfigurationVariable = resolver("sidecar configuration variable", listSidecarConfigurationVariables, by("name", "name"))
+)
+
+// Extractor resolves an extractor title in the input.
+func Extractor(ctx context.Context, cl client.Client, inputID, key string) (string, error) {
+	sel, value, explicit := parseKey(key, []selector{by("title", "title")})
+	if sel == selectorID || (!explicit && IsObjectID(value)) {
+		return value, nil
+	}
+	body, _, err := cl.Extractor.Gets(ctx, inputID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list extractors of the input %s to import %q: %w", inputID, key, err)
+	}
+	extractors, err := util.GetList(body, "extractors")
+	if err != nil {
+		return "", err
+	}
+	return find("extractor", sel.attr, value, extractors)
+}
+
+// User resolves a user ID to the username, because the username is the ID of graylog_user.
+// A username, with or without the selector "username:", is used as is.
+func User(ctx context.Context, cl client.Client, key string) (string, error) {
+	sel, value, explicit := parseKey(key, []selector{by("username", "username")})
+	if sel != selectorID && (explicit || !IsObjectID(value)) {
+		return value, nil
+	}
+	data, _, err := cl.User.GetByID(ctx, value)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a user %s: %w", value, err)
+	}
+	username, _ := data["username"].(string)
+	if username == "" {
+		return "", fmt.Errorf("the response of Graylog API is unexpected. username of the user %s is empty", value)
+	}
+	return username, nil
+}
+
+func listStreams(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.Stream.Gets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetList(body, "streams")
+}
+
+func listIndexSets(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.IndexSet.Gets(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetList(body, "index_sets")
+}
+
+func listIndexSetTemplates(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	return util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.IndexSetTemplate.Gets(ctx, &indextemplate.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "elements")
+}
+
+func listIndexFieldTypeProfiles(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	return util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.IndexFieldTypeProfile.Gets(ctx, &profile.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "elements")
+}
+
+func listInputs(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.Input.Gets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetList(body, "inputs")
+}
+
+func listOutputs(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.Output.Gets(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetList(body, "outputs")
+}
+
+func listPipelines(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	pipelines, _, err := cl.Pipeline.Gets(ctx)
+	return pipelines, err
+}
+
+func listPipelineRules(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	rules, _, err := cl.PipelineRule.Gets(ctx)
+	return rules, err
+}
+
+func listEventDefinitions(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	return util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.EventDefinition.Gets(ctx, &definition.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "event_definitions")
+}
+
+func listEventNotifications(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	return util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.EventNotification.Gets(ctx, &notification.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "notifications")
+}
+
+func listDashboards(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	return util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.Dashboard.Gets(ctx, &dashboard.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "elements", "views")
+}
+
+func listViews(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	return util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.View.Gets(ctx, &view.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "views", "elements")
+}
+
+func listSavedSearches(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.SavedSearch.Gets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetList(body, "views", "elements")
+}
+
+func listGrokPatterns(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.Grok.Gets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetList(body, "patterns")
+}
+
+func listSidecarCollectors(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	body, _, err := cl.Collector.Gets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetList(body, "collectors")
+}
+
+func listSidecarConfigurations(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	return util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, _, err := cl.SidecarConfiguration.Gets(ctx, &configuration.GetAllParams{Page: page, PerPage: perPage})
+		return body, err
+	}, "configurations")
+}
+
+func listSidecarConfigurationVariables(ctx context.Context, cl client.Client) ([]map[string]interface{}, error) {
+	variables, _, err := cl.SidecarVariable.Gets(ctx)
+	return variables, err
+}
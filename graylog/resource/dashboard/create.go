@@ -30,6 +30,17 @@ func create(d *schema.ResourceData, m interface{}) error {
 		return errors.New("dashboard state is empty")
 	}
 
+	parameters, err := expandParameters(data[keyParameter])
+	if err != nil {
+		return err
+	}
+	globalOverride, err := expandOverride(data[keyOverride])
+	if err != nil {
+		return err
+	}
+	delete(data, keyParameter)
+	delete(data, keyOverride)
+
 	defaultTimerange := getDefaultTimerange()
 	queries := make([]interface{}, 0, len(stateMap))
 
@@ -45,7 +56,14 @@ func create(d *schema.ResourceData, m interface{}) error {
 		queryString, _ := state[keyQueryString].(string)
 		delete(state, keyQueryString)
 
-		query, widgetMapping, err := generateQueryFromWidgets(stateID, queryString, widgets, defaultTimerange)
+		// Per-tab override takes precedence over the dashboard wide override
+		tabOverride, err := expandOverride(state[keyOverride])
+		if err != nil {
+			return fmt.Errorf("tab %s: %w", stateID, err)
+		}
+		delete(state, keyOverride)
+
+		query, widgetMapping, err := generateQueryFromWidgets(stateID, queryString, widgets, defaultTimerange, globalOverride.merge(tabOverride))
 		if err != nil {
 			return fmt.Errorf("failed to generate search query for tab %s: %w", stateID, err)
 		}
@@ -53,7 +71,7 @@ func create(d *schema.ResourceData, m interface{}) error {
 		applyWidgetMappingToState(state, widgetMapping)
 	}
 
-	searchData := buildSearchObject(queries)
+	searchData := buildSearchObject(queries, parameters)
 
 	// Create the search first
 	searchResp, _, err := cl.ViewSearch.Create(ctx, searchData)
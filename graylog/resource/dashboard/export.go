@@ -0,0 +1,65 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/hclgen"
+)
+
+// exportExcludedKeys are the attributes which are managed by the provider and aren't exported.
+var exportExcludedKeys = map[string]struct{}{
+	"type":           {},
+	"search_id":      {},
+	"owner":          {},
+	keyWidgetMapping: {},
+}
+
+// Export gets a dashboard and converts it to the attributes of graylog_dashboard.
+// Attributes which are computed or have default values are omitted.
+func Export(ctx context.Context, cl client.Client, id string) (map[string]interface{}, error) {
+	data, _, err := getDashboard(ctx, cl, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a dashboard %s: %w", id, err)
+	}
+	return exportValues(data)
+}
+
+// exportValues converts a dashboard view to the attributes of graylog_dashboard.
+func exportValues(data map[string]interface{}) (map[string]interface{}, error) {
+	rsc := Resource()
+	d := rsc.Data(nil)
+	if err := setDataToResourceData(d, data); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(rsc.Schema))
+	for k := range rsc.Schema {
+		values[k] = d.Get(k)
+	}
+	values = hclgen.Clean(rsc.Schema, values)
+	for k := range exportExcludedKeys {
+		delete(values, k)
+	}
+	params, _ := values[keyParameter].([]interface{})
+	for _, a := range params {
+		// title defaults to name
+		if p := a.(map[string]interface{}); p["title"] == p["name"] {
+			delete(p, "title")
+		}
+	}
+	states, _ := values[keyState].([]interface{})
+	for _, s := range states {
+		state := s.(map[string]interface{})
+		delete(state, keyWidgetMapping)
+		// width and height are used only by the automatic layout
+		if state[keyLayout] == nil {
+			widgets, _ := state[keyWidgets].([]interface{})
+			for _, w := range widgets {
+				delete(w.(map[string]interface{}), keyWidth)
+				delete(w.(map[string]interface{}), keyHeight)
+			}
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,73 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/hclgen"
+)
+
+func TestExportValues(t *testing.T) {
+	const body = `{
+		"id":"view-id",
+		"title":"Network Overview",
+		"description":"network",
+		"type":"DASHBOARD",
+		"search_id":"search-id",
+		"owner":"admin",
+		"state":{
+			"query-1":{
+				"widget_mapping":{"top-sources":["search-type-1"]},
+				"positions":{"top-sources":{"col":1,"row":1,"height":3,"width":"Infinity"}},
+				"widgets":[{
+					"id":"top-sources",
+					"type":"aggregation",
+					"config":{"row_pivots":[],"column_pivots":[],"series":[{"function":"count()","config":{}}],"sort":[],"visualization":"bar","rollup":true},
+					"timerange":{"type":"relative","range":300}
+				}],
+				"titles":{"tab":{"title":"Overview"},"widget":{"top-sources":"Top Sources"}},
+				"query_string":"level:3"
+			}
+		},
+		"parameter":[{"name":"source","type":"value-parameter-v1","title":"source","description":"","data_type":"any","default_value":"","optional":false}]
+	}`
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		t.Fatalf("failed to unmarshal fixture: %v", err)
+	}
+	values, err := exportValues(data)
+	if err != nil {
+		t.Fatalf("exportValues returned error: %v", err)
+	}
+	for _, k := range []string{"id", "type", "search_id", "owner", "created_at"} {
+		if _, ok := values[k]; ok {
+			t.Fatalf("%s shouldn't be exported", k)
+		}
+	}
+	state := values[keyState].([]interface{})[0].(map[string]interface{})
+	if state[keyTabTitle] != "Overview" || state[keyQueryString] != "level:3" {
+		t.Fatalf("unexpected state: %v", state)
+	}
+	if _, ok := state[keyWidgetMapping]; ok {
+		t.Fatal("widget_mapping shouldn't be exported")
+	}
+	widget := state[keyWidgets].([]interface{})[0].(map[string]interface{})
+	if _, ok := widget[keyWidth]; ok {
+		t.Fatal("width shouldn't be exported if the layout is manual")
+	}
+
+	hcl := string(hclgen.Resource("graylog_dashboard", "network_overview", Resource(), values))
+	for _, s := range []string{
+		`resource "graylog_dashboard" "network_overview" {`,
+		`title       = "Network Overview"`,
+		`tab_title    = "Overview"`,
+		`widget_id = "top-sources"`,
+		`timerange = jsonencode({`,
+		`name = "source"`,
+	} {
+		if !strings.Contains(hcl, s) {
+			t.Fatalf("the exported HCL should contain %q:\n%s", s, hcl)
+		}
+	}
+}
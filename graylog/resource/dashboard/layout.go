@@ -0,0 +1,261 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyLayout = "layout"
+	keyWidth  = "width"
+	keyHeight = "height"
+
+	layoutManual = "manual"
+	layoutAuto   = "auto"
+
+	gridColumns         = 12
+	defaultWidgetWidth  = 4
+	defaultWidgetHeight = 4
+)
+
+// gridPosition is the position of a widget on the dashboard grid. col and row start from 1.
+type gridPosition struct {
+	col    int
+	row    int
+	width  int
+	height int
+}
+
+func (p gridPosition) toData() map[string]interface{} {
+	return map[string]interface{}{
+		"col":    p.col,
+		"row":    p.row,
+		"width":  p.width,
+		"height": p.height,
+	}
+}
+
+// parseGridPosition parses a position of the positions JSON.
+// A width of "Infinity" spans the remaining columns of the grid.
+func parseGridPosition(v interface{}) (gridPosition, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return gridPosition{}, errors.New("position must be an object")
+	}
+	pos := gridPosition{}
+	for k, dst := range map[string]*int{"col": &pos.col, "row": &pos.row, "width": &pos.width, "height": &pos.height} {
+		switch n := m[k].(type) {
+		case float64:
+			*dst = int(n)
+		case int:
+			*dst = n
+		case string:
+			if n == "Infinity" {
+				*dst = gridColumns
+				continue
+			}
+			i, err := strconv.Atoi(n)
+			if err != nil {
+				return gridPosition{}, fmt.Errorf("position %s is invalid: %w", k, err)
+			}
+			*dst = i
+		default:
+			return gridPosition{}, fmt.Errorf("position %s is required", k)
+		}
+	}
+	if pos.col < 1 || pos.row < 1 || pos.width < 1 || pos.height < 1 {
+		return gridPosition{}, errors.New("col, row, width and height of position must be positive")
+	}
+	return pos, nil
+}
+
+// grid tracks the occupied cells of the dashboard grid.
+type grid map[int][gridColumns + 1]bool
+
+func (g grid) fits(pos gridPosition) bool {
+	if pos.col+pos.width-1 > gridColumns {
+		return false
+	}
+	for r := pos.row; r < pos.row+pos.height; r++ {
+		cells := g[r]
+		for c := pos.col; c < pos.col+pos.width; c++ {
+			if cells[c] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (g grid) occupy(pos gridPosition) {
+	for r := pos.row; r < pos.row+pos.height; r++ {
+		cells := g[r]
+		for c := pos.col; c < pos.col+pos.width && c <= gridColumns; c++ {
+			cells[c] = true
+		}
+		g[r] = cells
+	}
+}
+
+// widgetSize is the declared size of a widget laid out automatically.
+type widgetSize struct {
+	id     string
+	width  int
+	height int
+}
+
+// computeLayout computes the positions of widgets in the declared order.
+// Widgets are packed into rows of the 12 columns grid from left to right and top to bottom.
+// The positions in fixed are kept as they are and the other widgets are placed around them.
+func computeLayout(widgets []widgetSize, fixed map[string]interface{}) (map[string]interface{}, error) {
+	positions := make(map[string]interface{}, len(widgets)+len(fixed))
+	g := grid{}
+	for id, v := range fixed {
+		pos, err := parseGridPosition(v)
+		if err != nil {
+			return nil, fmt.Errorf("positions of widget %s: %w", id, err)
+		}
+		g.occupy(pos)
+		positions[id] = v
+	}
+
+	row, col := 1, 1
+	for _, w := range widgets {
+		if _, ok := fixed[w.id]; ok {
+			continue
+		}
+		if w.width < 1 || w.width > gridColumns {
+			return nil, fmt.Errorf("width of widget %s must be between 1 and %d", w.id, gridColumns)
+		}
+		if w.height < 1 {
+			return nil, fmt.Errorf("height of widget %s must be positive", w.id)
+		}
+		// search the first free cell from the cursor so that the declared order is kept
+		pos := gridPosition{row: row, col: col, width: w.width, height: w.height}
+		for !g.fits(pos) {
+			pos.col++
+			if pos.col+pos.width-1 > gridColumns {
+				pos.col = 1
+				pos.row++
+			}
+		}
+		g.occupy(pos)
+		positions[w.id] = pos.toData()
+		row, col = pos.row, pos.col+pos.width
+		if col > gridColumns {
+			row, col = row+1, 1
+		}
+	}
+	return positions, nil
+}
+
+// expandLayout computes the positions of a state block whose layout is auto.
+// widgets are the widgets blocks and positions are the user supplied positions.
+func expandLayout(widgets []interface{}, positions map[string]interface{}) (map[string]interface{}, error) {
+	sizes := make([]widgetSize, 0, len(widgets))
+	for i, a := range widgets {
+		widget, _ := a.(map[string]interface{})
+		id, _ := widget[keyWidgetID].(string)
+		if id == "" {
+			return nil, fmt.Errorf("widgets.%d: widget_id is required if layout is auto", i)
+		}
+		size := widgetSize{id: id, width: intValue(widget[keyWidth]), height: intValue(widget[keyHeight])}
+		if size.width == 0 {
+			size.width = defaultWidgetWidth
+		}
+		if size.height == 0 {
+			size.height = defaultWidgetHeight
+		}
+		sizes = append(sizes, size)
+	}
+	return computeLayout(sizes, positions)
+}
+
+// schemaDiffSuppressPositions suppresses the diff of positions if the layout is auto and
+// the computed layout is same as the current positions.
+func schemaDiffSuppressPositions(k, oldV, newV string, d *schema.ResourceData) bool {
+	prefix := strings.TrimSuffix(k, keyPositions)
+	if d.Get(prefix+keyLayout).(string) != layoutAuto {
+		return util.SchemaDiffSuppressJSONString(k, oldV, newV, d)
+	}
+	fixed := map[string]interface{}{}
+	if newV != "" {
+		if err := json.Unmarshal([]byte(newV), &fixed); err != nil {
+			return false
+		}
+	}
+	widgets, _ := d.Get(prefix + keyWidgets).([]interface{})
+	computed, err := expandLayout(widgets, fixed)
+	if err != nil {
+		return false
+	}
+	var current interface{}
+	if err := json.Unmarshal([]byte(oldV), &current); err != nil {
+		return false
+	}
+	b, err := json.Marshal(computed)
+	if err != nil {
+		return false
+	}
+	var expected interface{}
+	if err := json.Unmarshal(b, &expected); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(current, expected)
+}
+
+// getWidgetSizes returns the width and height of widgets in the stored state.
+func getWidgetSizes(d *schema.ResourceData) map[string][2]int {
+	sizes := map[string][2]int{}
+	stored, _ := d.Get(keyState).([]interface{})
+	for _, s := range stored {
+		sm, _ := s.(map[string]interface{})
+		widgets, _ := sm[keyWidgets].([]interface{})
+		for _, w := range widgets {
+			widget, _ := w.(map[string]interface{})
+			id, _ := widget[keyWidgetID].(string)
+			if id == "" {
+				continue
+			}
+			sizes[id] = [2]int{intValue(widget[keyWidth]), intValue(widget[keyHeight])}
+		}
+	}
+	return sizes
+}
+
+// getLayouts returns the layout of each state block in the stored state.
+func getLayouts(d *schema.ResourceData) map[string]string {
+	layouts := map[string]string{}
+	stored, _ := d.Get(keyState).([]interface{})
+	for _, s := range stored {
+		sm, _ := s.(map[string]interface{})
+		if id, _ := sm[keyID].(string); id != "" {
+			layouts[id], _ = sm[keyLayout].(string)
+		}
+	}
+	return layouts
+}
+
+// flattenWidgetSize sets the width and height of a widget.
+// The sizes in the stored state are kept, otherwise they are read from the widget position.
+func flattenWidgetSize(widget map[string]interface{}, stored [2]int, positions map[string]interface{}) {
+	widget[keyWidth], widget[keyHeight] = stored[0], stored[1]
+	id, _ := widget[keyWidgetID].(string)
+	pos, err := parseGridPosition(positions[id])
+	if err != nil {
+		return
+	}
+	if stored[0] == 0 {
+		widget[keyWidth] = pos.width
+	}
+	if stored[1] == 0 {
+		widget[keyHeight] = pos.height
+	}
+}
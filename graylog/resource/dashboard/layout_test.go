@@ -0,0 +1,105 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestComputeLayout(t *testing.T) {
+	data := []struct {
+		title   string
+		widgets []widgetSize
+		fixed   string
+		exp     map[string]gridPosition
+	}{
+		{
+			title: "row packing",
+			widgets: []widgetSize{
+				{id: "a", width: 6, height: 4},
+				{id: "b", width: 6, height: 2},
+				{id: "c", width: 4, height: 2},
+				{id: "d", width: 12, height: 3},
+			},
+			exp: map[string]gridPosition{
+				"a": {col: 1, row: 1, width: 6, height: 4},
+				"b": {col: 7, row: 1, width: 6, height: 2},
+				"c": {col: 7, row: 3, width: 4, height: 2},
+				"d": {col: 1, row: 5, width: 12, height: 3},
+			},
+		},
+		{
+			title: "user supplied positions are kept",
+			widgets: []widgetSize{
+				{id: "a", width: 4, height: 2},
+				{id: "b", width: 4, height: 2},
+				{id: "c", width: 8, height: 2},
+			},
+			fixed: `{"b":{"col":1,"row":1,"width":"Infinity","height":2}}`,
+			exp: map[string]gridPosition{
+				"a": {col: 1, row: 3, width: 4, height: 2},
+				"b": {col: 1, row: 1, width: gridColumns, height: 2},
+				"c": {col: 5, row: 3, width: 8, height: 2},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			fixed := map[string]interface{}{}
+			if d.fixed != "" {
+				if err := json.Unmarshal([]byte(d.fixed), &fixed); err != nil {
+					t.Fatal(err)
+				}
+			}
+			positions, err := computeLayout(d.widgets, fixed)
+			if err != nil {
+				t.Fatalf("computeLayout returned error: %v", err)
+			}
+			if len(positions) != len(d.exp) {
+				t.Fatalf("positions = %v, want %d positions", positions, len(d.exp))
+			}
+			for id, exp := range d.exp {
+				pos, err := parseGridPosition(positions[id])
+				if err != nil {
+					t.Fatalf("position of %s is invalid: %v", id, err)
+				}
+				if pos != exp {
+					t.Fatalf("position of %s = %+v, want %+v", id, pos, exp)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeLayoutInvalidWidth(t *testing.T) {
+	if _, err := computeLayout([]widgetSize{{id: "a", width: 13, height: 1}}, nil); err == nil {
+		t.Fatal("computeLayout should return an error if width exceeds the grid")
+	}
+}
+
+func TestSchemaDiffSuppressPositions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Resource().Schema, map[string]interface{}{
+		"title": "test",
+		"state": []interface{}{
+			map[string]interface{}{
+				"layout": layoutAuto,
+				"widgets": []interface{}{
+					map[string]interface{}{"widget_id": "a", "config": "{}", "width": 6, "height": 2},
+					map[string]interface{}{"widget_id": "b", "config": "{}"},
+				},
+			},
+		},
+	})
+	computed := `{"a":{"col":1,"row":1,"width":6,"height":2},"b":{"col":7,"row":1,"width":4,"height":4}}`
+	if !schemaDiffSuppressPositions("state.0.positions", computed, "", d) {
+		t.Fatal("diff should be suppressed if the computed layout isn't changed")
+	}
+	moved := `{"a":{"col":1,"row":1,"width":6,"height":2},"b":{"col":1,"row":3,"width":4,"height":4}}`
+	if schemaDiffSuppressPositions("state.0.positions", moved, "", d) {
+		t.Fatal("diff shouldn't be suppressed if the computed layout is changed")
+	}
+	if !schemaDiffSuppressPositions("state.0.positions", moved, `{"b":{"col":1,"row":3,"width":4,"height":4}}`, d) {
+		t.Fatal("diff should be suppressed if the user supplied position is kept")
+	}
+}
@@ -0,0 +1,375 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyParameter  = "parameter"
+	keyParameters = "parameters"
+	keyOverride   = "override"
+	keyStreams    = "streams"
+
+	parameterTypeValue       = "value-parameter-v1"
+	parameterTypeLookupTable = "lookup-table-parameter-v1"
+)
+
+// parameterNamePattern matches the names which can be referred as $name$ in queries.
+var parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var schemaParameter = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(parameterNamePattern, "name must consist of letters, digits and underscores"),
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      parameterTypeValue,
+				ValidateFunc: validation.StringInSlice([]string{parameterTypeValue, parameterTypeLookupTable}, false),
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"data_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "any",
+			},
+			"default_value": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"optional": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			// lookup-table-parameter-v1
+			"lookup_table": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	},
+}
+
+// schemaOverride overrides the timerange, query and streams of the dashboard or a tab.
+var schemaOverride = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"timerange": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				ValidateFunc:     util.ValidateIsJSON,
+			},
+			"query_string": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"streams": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	},
+}
+
+// expandParameters converts the parameter blocks to the parameters of the search.
+func expandParameters(v interface{}) ([]interface{}, error) {
+	list, _ := v.([]interface{})
+	params := make([]interface{}, 0, len(list))
+	names := make(map[string]struct{}, len(list))
+	for _, a := range list {
+		p, _ := a.(map[string]interface{})
+		name, _ := p["name"].(string)
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("parameter %s is duplicated", name)
+		}
+		names[name] = struct{}{}
+		title, _ := p["title"].(string)
+		if title == "" {
+			title = name
+		}
+		var defaultValue interface{}
+		if s, _ := p["default_value"].(string); s != "" {
+			defaultValue = s
+		}
+		param := map[string]interface{}{
+			"type":          p["type"],
+			"name":          name,
+			"title":         title,
+			"description":   p["description"],
+			"data_type":     p["data_type"],
+			"default_value": defaultValue,
+			"optional":      p["optional"],
+			"binding":       nil,
+		}
+		lookupTable, _ := p["lookup_table"].(string)
+		key, _ := p["key"].(string)
+		if p["type"] == parameterTypeLookupTable {
+			if lookupTable == "" || key == "" {
+				return nil, fmt.Errorf("lookup_table and key of parameter %s are required for %s", name, parameterTypeLookupTable)
+			}
+			param["lookup_table"] = lookupTable
+			param["key"] = key
+		} else if lookupTable != "" || key != "" {
+			return nil, fmt.Errorf("lookup_table and key of parameter %s can be set only for %s", name, parameterTypeLookupTable)
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// flattenParameters converts the parameters of the search to the parameter blocks.
+func flattenParameters(params []interface{}) []interface{} {
+	list := make([]interface{}, 0, len(params))
+	for _, a := range params {
+		p, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		elem := map[string]interface{}{
+			"name":          p["name"],
+			"type":          stringOr(p, "type", parameterTypeValue),
+			"title":         p["title"],
+			"description":   p["description"],
+			"data_type":     stringOr(p, "data_type", "any"),
+			"default_value": "",
+			"optional":      p["optional"],
+			"lookup_table":  stringOr(p, "lookup_table", ""),
+			"key":           stringOr(p, "key", ""),
+		}
+		if dv := p["default_value"]; dv != nil {
+			elem["default_value"] = fmt.Sprint(dv)
+		}
+		list = append(list, elem)
+	}
+	return list
+}
+
+// queryOverride is the timerange, query and streams applied to the queries of the search.
+type queryOverride struct {
+	timerange   map[string]interface{}
+	queryString string
+	streams     []interface{}
+}
+
+// expandOverride converts an override block to queryOverride.
+func expandOverride(v interface{}) (queryOverride, error) {
+	o := queryOverride{}
+	list, _ := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return o, nil
+	}
+	block := list[0].(map[string]interface{})
+	if s, _ := block["timerange"].(string); s != "" {
+		tr, err := convert.StringJSONToData(s)
+		if err != nil {
+			return o, fmt.Errorf("failed to parse override timerange: %w", err)
+		}
+		o.timerange = tr
+	}
+	o.queryString, _ = block["query_string"].(string)
+	switch streams := block[keyStreams].(type) {
+	case *schema.Set:
+		o.streams = streams.List()
+	case []interface{}:
+		o.streams = streams
+	}
+	return o, nil
+}
+
+// merge returns the override whose values are overridden by the values of tab.
+func (o queryOverride) merge(tab queryOverride) queryOverride {
+	if tab.timerange != nil {
+		o.timerange = tab.timerange
+	}
+	if tab.queryString != "" {
+		o.queryString = tab.queryString
+	}
+	if len(tab.streams) != 0 {
+		o.streams = tab.streams
+	}
+	return o
+}
+
+// buildQueryFilter builds the persistent filter of a query from the tab's query string and the streams.
+func buildQueryFilter(queryString string, streams []interface{}) interface{} {
+	filters := []interface{}{}
+	if len(streams) != 0 {
+		streamFilters := make([]interface{}, len(streams))
+		for i, s := range streams {
+			streamFilters[i] = map[string]interface{}{"type": "stream", "id": s}
+		}
+		filters = append(filters, map[string]interface{}{
+			"type":    "or",
+			"filters": streamFilters,
+		})
+	}
+	if queryString != "" {
+		filters = append(filters, map[string]interface{}{
+			"type": "or",
+			"filters": []interface{}{
+				map[string]interface{}{
+					"type":    "query_string",
+					"query":   queryString,
+					"filters": []interface{}{},
+				},
+			},
+		})
+	}
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	}
+	return map[string]interface{}{
+		"type":    "and",
+		"filters": filters,
+	}
+}
+
+// findFilters returns the filters of the given type in the filter tree.
+func findFilters(filter map[string]interface{}, filterType string) []map[string]interface{} {
+	var found []map[string]interface{}
+	if t, _ := filter["type"].(string); t == filterType {
+		found = append(found, filter)
+	}
+	children, _ := filter["filters"].([]interface{})
+	for _, c := range children {
+		if child, ok := c.(map[string]interface{}); ok {
+			found = append(found, findFilters(child, filterType)...)
+		}
+	}
+	return found
+}
+
+// injectParametersFromSearch sets the parameters of the search to the view data.
+func injectParametersFromSearch(viewData, searchData map[string]interface{}) {
+	params, _ := searchData[keyParameters].([]interface{})
+	viewData[keyParameter] = flattenParameters(params)
+}
+
+// injectOverridesFromSearch sets the timerange, query and streams of the search queries to the view state entries.
+func injectOverridesFromSearch(viewData, searchData map[string]interface{}) {
+	stateMap, ok := viewData[keyState].(map[string]interface{})
+	if !ok {
+		return
+	}
+	queries, _ := searchData["queries"].([]interface{})
+	for _, q := range queries {
+		query, ok := q.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		qID, _ := query["id"].(string)
+		state, ok := stateMap[qID].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		override := map[string]interface{}{
+			keyStreams: []interface{}{},
+		}
+		if tr, ok := query["timerange"].(map[string]interface{}); ok {
+			override["timerange"] = tr
+		}
+		if qObj, ok := query["query"].(map[string]interface{}); ok {
+			override["query_string"], _ = qObj["query_string"].(string)
+		}
+		if filter, ok := query["filter"].(map[string]interface{}); ok {
+			streams := []interface{}{}
+			for _, f := range findFilters(filter, "stream") {
+				if id, ok := f["id"].(string); ok {
+					streams = append(streams, id)
+				}
+			}
+			override[keyStreams] = streams
+		}
+		state[keyOverride] = override
+	}
+}
+
+// getStoredOverrides returns the override blocks of the state blocks in the stored state.
+func getStoredOverrides(d *schema.ResourceData) map[string]map[string]interface{} {
+	overrides := map[string]map[string]interface{}{}
+	stored, _ := d.Get(keyState).([]interface{})
+	for _, s := range stored {
+		sm, _ := s.(map[string]interface{})
+		id, _ := sm[keyID].(string)
+		list, _ := sm[keyOverride].([]interface{})
+		if id == "" || len(list) == 0 {
+			continue
+		}
+		if block, ok := list[0].(map[string]interface{}); ok {
+			overrides[id] = block
+		} else {
+			overrides[id] = map[string]interface{}{}
+		}
+	}
+	return overrides
+}
+
+// getStoredOverrideQueryString returns the query string of the dashboard wide override block in the stored state.
+func getStoredOverrideQueryString(d *schema.ResourceData) string {
+	list, _ := d.Get(keyOverride).([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return ""
+	}
+	qs, _ := list[0].(map[string]interface{})["query_string"].(string)
+	return qs
+}
+
+// flattenOverride converts the override read from the search query to the override block of a tab.
+// Only the attributes set in the stored block are read to detect drift, because the others are
+// inherited from the dashboard wide override or the defaults.
+func flattenOverride(override, stored map[string]interface{}) ([]interface{}, error) {
+	block := map[string]interface{}{
+		"timerange":    "",
+		"query_string": "",
+		keyStreams:     []interface{}{},
+	}
+	if s, _ := stored["timerange"].(string); s != "" {
+		if tr, ok := override["timerange"].(map[string]interface{}); ok {
+			b, err := json.Marshal(tr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal override timerange: %w", err)
+			}
+			block["timerange"] = string(b)
+		}
+	}
+	if s, _ := stored["query_string"].(string); s != "" {
+		block["query_string"] = override["query_string"]
+	}
+	if set, ok := stored[keyStreams].(*schema.Set); ok && set.Len() != 0 {
+		block[keyStreams] = override[keyStreams]
+	}
+	return []interface{}{block}, nil
+}
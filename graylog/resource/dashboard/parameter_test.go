@@ -0,0 +1,129 @@
+package dashboard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandParameters(t *testing.T) {
+	params, err := expandParameters([]interface{}{
+		map[string]interface{}{
+			"name": "source", "type": parameterTypeValue, "title": "", "description": "source host",
+			"data_type": "any", "default_value": "web-01", "optional": false,
+		},
+		map[string]interface{}{
+			"name": "team", "type": parameterTypeValue, "title": "Team", "description": "",
+			"data_type": "any", "default_value": "", "optional": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("expandParameters returned error: %v", err)
+	}
+	source := params[0].(map[string]interface{})
+	if source["title"] != "source" || source["default_value"] != "web-01" {
+		t.Fatalf("unexpected parameter: %v", source)
+	}
+	if team := params[1].(map[string]interface{}); team["default_value"] != nil {
+		t.Fatalf("default_value should be null if it's empty: %v", team)
+	}
+
+	flattened := flattenParameters(params)
+	exp := map[string]interface{}{
+		"name": "source", "type": parameterTypeValue, "title": "source", "description": "source host",
+		"data_type": "any", "default_value": "web-01", "optional": false, "lookup_table": "", "key": "",
+	}
+	if !reflect.DeepEqual(flattened[0], exp) {
+		t.Fatalf("flattenParameters = %v, want %v", flattened[0], exp)
+	}
+
+	if _, err := expandParameters([]interface{}{
+		map[string]interface{}{"name": "source"},
+		map[string]interface{}{"name": "source"},
+	}); err == nil {
+		t.Fatal("expandParameters should return an error if a parameter is duplicated")
+	}
+
+	params, err = expandParameters([]interface{}{
+		map[string]interface{}{
+			"name": "owner", "type": parameterTypeLookupTable, "title": "", "description": "",
+			"data_type": "any", "default_value": "", "optional": false,
+			"lookup_table": "host-owners", "key": "web-01",
+		},
+	})
+	if err != nil {
+		t.Fatalf("expandParameters returned error: %v", err)
+	}
+	if owner := params[0].(map[string]interface{}); owner["lookup_table"] != "host-owners" || owner["key"] != "web-01" {
+		t.Fatalf("lookup_table and key should be set: %v", owner)
+	}
+	if flattened := flattenParameters(params)[0].(map[string]interface{}); flattened["lookup_table"] != "host-owners" {
+		t.Fatalf("flattenParameters should read lookup_table: %v", flattened)
+	}
+
+	if _, err := expandParameters([]interface{}{
+		map[string]interface{}{"name": "owner", "type": parameterTypeLookupTable, "lookup_table": "host-owners"},
+	}); err == nil {
+		t.Fatal("expandParameters should return an error if key of a lookup table parameter is empty")
+	}
+	if _, err := expandParameters([]interface{}{
+		map[string]interface{}{"name": "source", "type": parameterTypeValue, "key": "web-01"},
+	}); err == nil {
+		t.Fatal("expandParameters should return an error if key is set to a value parameter")
+	}
+}
+
+func TestGenerateQueryFromWidgetsOverride(t *testing.T) {
+	global, err := expandOverride([]interface{}{
+		map[string]interface{}{
+			"timerange":    `{"type":"relative","range":3600}`,
+			"query_string": "source:$source$",
+			"streams":      []interface{}{"stream-a"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expandOverride returned error: %v", err)
+	}
+	tab, err := expandOverride([]interface{}{
+		map[string]interface{}{"timerange": "", "query_string": "", "streams": []interface{}{"stream-b"}},
+	})
+	if err != nil {
+		t.Fatalf("expandOverride returned error: %v", err)
+	}
+	widgets := []interface{}{
+		map[string]interface{}{
+			"id":   "messages-1",
+			"type": widgetTypeMessages,
+			"config": map[string]interface{}{
+				"fields": []interface{}{"timestamp", "message"},
+			},
+		},
+	}
+	query, _, err := generateQueryFromWidgets("query-1", "level:3", widgets, getDefaultTimerange(), global.merge(tab))
+	if err != nil {
+		t.Fatalf("generateQueryFromWidgets returned error: %v", err)
+	}
+	tr := map[string]interface{}{"type": "relative", "range": float64(3600)}
+	if !reflect.DeepEqual(query["timerange"], tr) {
+		t.Fatalf("query timerange = %v, want %v", query["timerange"], tr)
+	}
+	searchType := query["search_types"].([]interface{})[0].(map[string]interface{})
+	if !reflect.DeepEqual(searchType["timerange"], tr) {
+		t.Fatalf("search type timerange = %v, want %v", searchType["timerange"], tr)
+	}
+	if qs := query["query"].(map[string]interface{})["query_string"]; qs != "source:$source$" {
+		t.Fatalf("query_string = %v, want source:$source$", qs)
+	}
+
+	filter := query["filter"].(map[string]interface{})
+	if filter["type"] != "and" {
+		t.Fatalf("filter type = %v, want and", filter["type"])
+	}
+	streams := findFilters(filter, "stream")
+	if len(streams) != 1 || streams[0]["id"] != "stream-b" {
+		t.Fatalf("stream filters = %v, want stream-b", streams)
+	}
+	queryStrings := findFilters(filter, "query_string")
+	if len(queryStrings) != 1 || queryStrings[0]["query"] != "level:3" {
+		t.Fatalf("query_string filters = %v, want level:3", queryStrings)
+	}
+}
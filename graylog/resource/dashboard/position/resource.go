@@ -2,6 +2,7 @@ package position
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -16,7 +17,7 @@ func Resource() *schema.Resource {
 		StateUpgraders: stateUpgraders,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.Dashboard),
 		},
 
 		Schema: map[string]*schema.Schema{
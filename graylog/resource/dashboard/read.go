@@ -3,6 +3,7 @@ package dashboard
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
@@ -16,19 +17,29 @@ func read(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	data, resp, err := cl.View.Get(ctx, d.Id())
+	data, resp, err := getDashboard(ctx, cl, d.Id())
 	if err != nil {
 		return util.HandleGetResourceError(
 			d, resp, fmt.Errorf("failed to get a dashboard %s: %w", d.Id(), err))
 	}
+	return setDataToResourceData(d, data)
+}
+
+// getDashboard gets a dashboard view and injects the attributes kept in its search.
+func getDashboard(ctx context.Context, cl client.Client, id string) (map[string]interface{}, *http.Response, error) {
+	data, resp, err := cl.View.Get(ctx, id)
+	if err != nil {
+		return nil, resp, err
+	}
 
 	// Fetch the search to extract per-widget streams and per-tab query strings
 	if searchID, ok := data["search_id"].(string); ok && searchID != "" {
 		if searchData, _, err := cl.ViewSearch.Get(ctx, searchID); err == nil {
 			injectStreamsFromSearch(data, searchData)
 			injectQueryFromSearch(data, searchData)
+			injectOverridesFromSearch(data, searchData)
+			injectParametersFromSearch(data, searchData)
 		}
 	}
-
-	return setDataToResourceData(d, data)
+	return data, resp, nil
 }
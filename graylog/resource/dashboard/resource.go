@@ -1,7 +1,11 @@
 package dashboard
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 )
 
 func Resource() *schema.Resource {
@@ -12,9 +16,39 @@ func Resource() *schema.Resource {
 		Delete: destroy,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.Dashboard),
 		},
 
 		Schema: schemaMap(),
+
+		CustomizeDiff: customizeDiff,
+	}
+}
+
+// customizeDiff validates the typed widget blocks and the parameters at plan time.
+func customizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.NewValueKnown(keyParameter) {
+		if _, err := expandParameters(d.Get(keyParameter)); err != nil {
+			return err
+		}
+	}
+	states, _ := d.Get(keyState).([]interface{})
+	for i, s := range states {
+		state, _ := s.(map[string]interface{})
+		widgets, _ := state[keyWidgets].([]interface{})
+		for j, w := range widgets {
+			widget, _ := w.(map[string]interface{})
+			name, block, err := getTypedWidgetBlock(widget)
+			if err != nil {
+				return fmt.Errorf("state.%d.widgets.%d: %w", i, j, err)
+			}
+			if name == "" || !d.NewValueKnown(fmt.Sprintf("%s.%d.%s.%d.%s", keyState, i, keyWidgets, j, name)) {
+				continue
+			}
+			if err := validateTypedWidgetBlock(name, block); err != nil {
+				return fmt.Errorf("state.%d.widgets.%d: %w", i, j, err)
+			}
+		}
 	}
+	return nil
 }
@@ -2,6 +2,7 @@ package dashboard
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -30,6 +31,10 @@ func schemaMap() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"state": schemaState,
+		// parameters of the search which can be referred as $name$ in queries
+		"parameter": schemaParameter,
+		// dashboard wide override which applies to all tabs
+		"override": schemaOverride,
 		"owner": {
 			Type:     schema.TypeString,
 			Optional: true,
@@ -52,6 +57,13 @@ var schemaState = &schema.Schema{
 			"tab_title":    {Type: schema.TypeString, Optional: true},
 			"query_string": {Type: schema.TypeString, Optional: true, Default: ""},
 			"widgets":      schemaWidgets,
+			// auto computes the positions of widgets without user supplied positions
+			"layout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      layoutManual,
+				ValidateFunc: validation.StringInSlice([]string{layoutManual, layoutAuto}, false),
+			},
 			"widget_mapping": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -62,10 +74,11 @@ var schemaState = &schema.Schema{
 			"positions": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				DiffSuppressFunc: schemaDiffSuppressPositions,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
-			"titles": schemaTitles,
+			"titles":   schemaTitles,
+			"override": schemaOverride,
 		},
 	},
 }
@@ -94,27 +107,51 @@ var schemaWidgets = &schema.Schema{
 	Type:     schema.TypeList,
 	Required: true,
 	Elem: &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"widget_id": {Type: schema.TypeString, Optional: true},
-			"type":      {Type: schema.TypeString, Required: true},
-			"config": {
-				Type:             schema.TypeString,
-				Required:         true,
-				DiffSuppressFunc: util.SchemaDiffSuppressJSONSubset,
-				ValidateFunc:     util.ValidateIsJSON,
-			},
-			"timerange": {
-				Type:             schema.TypeString,
-				Optional:         true,
-				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
-				ValidateFunc:     util.ValidateIsJSON,
-			},
-			"query": schemaQuery,
-			"streams": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-			},
-		},
+		Schema: widgetAttributes(),
 	},
 }
+
+// widgetAttributes returns the attributes of the widgets block.
+// Either config or one of the typed widget blocks (table, bar_chart, ...) must be set.
+func widgetAttributes() map[string]*schema.Schema {
+	attrs := map[string]*schema.Schema{
+		"widget_id": {Type: schema.TypeString, Optional: true},
+		// type is derived from the typed widget block if it's set
+		"type": {Type: schema.TypeString, Optional: true, Computed: true},
+		"config": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: util.SchemaDiffSuppressJSONSubset,
+			ValidateFunc:     util.ValidateIsJSON,
+		},
+		"timerange": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+			ValidateFunc:     util.ValidateIsJSON,
+		},
+		"query": schemaQuery,
+		// width and height are used if the layout of the state is auto
+		"width": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1, gridColumns),
+		},
+		"height": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"streams": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	for k, v := range typedWidgetSchemas() {
+		attrs[k] = v
+	}
+	return attrs
+}
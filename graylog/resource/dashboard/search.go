@@ -2,13 +2,19 @@ package dashboard
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
 // generateQueryFromWidgets creates a single search query entry with search_types derived from widget configs.
+// The timerange, query and streams of override are applied to the query.
 // It returns the query entry and a widget_mapping that links widget IDs to search_type IDs.
-func generateQueryFromWidgets(stateID string, queryString string, widgets []interface{}, defaultTimerange map[string]interface{}) (map[string]interface{}, map[string][]string, error) {
+func generateQueryFromWidgets(stateID string, queryString string, widgets []interface{}, defaultTimerange map[string]interface{}, override queryOverride) (map[string]interface{}, map[string][]string, error) {
+	if override.timerange != nil {
+		defaultTimerange = override.timerange
+	}
 	searchTypes := make([]interface{}, 0, len(widgets))
 	widgetMapping := make(map[string][]string)
 
@@ -26,8 +32,8 @@ func generateQueryFromWidgets(stateID string, queryString string, widgets []inte
 			widgetType = t
 		}
 
-		// Only aggregation and messages widgets need search_types
-		if widgetType != "aggregation" && widgetType != "messages" {
+		// Only aggregation, messages and events widgets need search_types
+		if widgetType != widgetTypeAggregation && widgetType != widgetTypeMessages && widgetType != widgetTypeEvents {
 			continue
 		}
 
@@ -52,14 +58,24 @@ func generateQueryFromWidgets(stateID string, queryString string, widgets []inte
 		}
 
 		var searchType map[string]interface{}
-		if widgetType == "messages" {
+		switch widgetType {
+		case widgetTypeMessages:
 			searchType = createMessagesSearchType(searchTypeID, config, widget[keyTimerange], defaultTimerange, widgetStreams, widgetQuery)
-		} else {
+		case widgetTypeEvents:
+			searchType = createEventsSearchType(searchTypeID, config, widget[keyTimerange], defaultTimerange, widgetStreams, widgetQuery)
+		default:
 			searchType = createSearchTypeFromWidgetConfig(searchTypeID, config, widget[keyTimerange], defaultTimerange, widgetStreams, widgetQuery)
 		}
 
 		searchTypes = append(searchTypes, searchType)
 		widgetMapping[widgetID] = []string{searchTypeID}
+
+		// Numeric widgets with trend compare the value with the previous timerange
+		if isTrendWidget(config) {
+			trendID := uuid.New().String()
+			searchTypes = append(searchTypes, createTrendSearchType(trendID, searchType))
+			widgetMapping[widgetID] = append(widgetMapping[widgetID], trendID)
+		}
 	}
 
 	// Build persistent filter from queryString so it survives UI interactions.
@@ -67,27 +83,14 @@ func generateQueryFromWidgets(stateID string, queryString string, widgets []inte
 	// so we use the "filter" field instead for persistent filtering.
 	// Note: The filter UI ("Search Filters") is a Graylog commercial edition feature.
 	// In open-source Graylog, the filter is applied but not visible in the dashboard UI.
-	var filter interface{}
-	if queryString != "" {
-		filter = map[string]interface{}{
-			"type": "or",
-			"filters": []interface{}{
-				map[string]interface{}{
-					"type":    "query_string",
-					"query":   queryString,
-					"filters": []interface{}{},
-				},
-			},
-		}
-	}
-
+	// The override query goes to query.query_string, which is the initial query of the search bar.
 	query := map[string]interface{}{
 		"id":      stateID,
 		"filters": []interface{}{},
-		"filter":  filter,
+		"filter":  buildQueryFilter(queryString, override.streams),
 		"query": map[string]interface{}{
 			"type":         "elasticsearch",
-			"query_string": "",
+			"query_string": override.queryString,
 		},
 		"timerange":    defaultTimerange,
 		"search_types": searchTypes,
@@ -96,10 +99,10 @@ func generateQueryFromWidgets(stateID string, queryString string, widgets []inte
 	return query, widgetMapping, nil
 }
 
-// buildSearchObject assembles multiple query entries into a complete search object.
-func buildSearchObject(queries []interface{}) map[string]interface{} {
+// buildSearchObject assembles multiple query entries and the parameters into a complete search object.
+func buildSearchObject(queries, parameters []interface{}) map[string]interface{} {
 	return map[string]interface{}{
-		"parameters":            []interface{}{},
+		"parameters":            parameters,
 		"skip_no_streams_check": false,
 		"queries":               queries,
 	}
@@ -176,6 +179,87 @@ func createMessagesSearchType(id string, config map[string]interface{}, widgetTi
 	return searchType
 }
 
+// createEventsSearchType creates a search_type for an events list widget.
+func createEventsSearchType(id string, config map[string]interface{}, widgetTimerange interface{}, defaultTimerange map[string]interface{}, streams []interface{}, widgetQuery map[string]interface{}) map[string]interface{} {
+	if streams == nil {
+		streams = []interface{}{}
+	}
+
+	var query interface{}
+	if widgetQuery != nil {
+		if qs, ok := widgetQuery["query_string"].(string); ok && qs != "" {
+			query = map[string]interface{}{
+				"type":         "elasticsearch",
+				"query_string": qs,
+			}
+		}
+	}
+
+	// Widget sort: {"type": "pivot", "field": "timestamp", "direction": "Descending"}
+	// Search type sort: {"field": "timestamp", "direction": "DESC"}
+	searchSort := map[string]interface{}{"field": "timestamp", "direction": "DESC"}
+	if sort, ok := config["sort"].(map[string]interface{}); ok {
+		if f, ok := sort["field"].(string); ok && f != "" {
+			searchSort["field"] = f
+		}
+		if dir, ok := sort["direction"].(string); ok && dir == "Ascending" {
+			searchSort["direction"] = "ASC"
+		}
+	}
+
+	searchType := map[string]interface{}{
+		"id":         id,
+		"type":       "events",
+		"name":       "events",
+		"filter":     nil,
+		"filters":    []interface{}{},
+		"query":      query,
+		"streams":    streams,
+		"page":       1,
+		"per_page":   10,
+		"sort":       searchSort,
+		"attributes": []interface{}{},
+	}
+
+	if tr, ok := widgetTimerange.(map[string]interface{}); ok && len(tr) > 0 {
+		searchType["timerange"] = tr
+	} else {
+		searchType["timerange"] = defaultTimerange
+	}
+
+	return searchType
+}
+
+// isTrendWidget returns true if the widget config is a numeric visualization with trend enabled.
+func isTrendWidget(config map[string]interface{}) bool {
+	if v, _ := config["visualization"].(string); v != "numeric" {
+		return false
+	}
+	vc, ok := config["visualization_config"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	trend, _ := vc["trend"].(bool)
+	return trend
+}
+
+// createTrendSearchType creates the search_type of the previous timerange for a numeric widget with trend.
+// It's the copy of the chart search_type whose timerange is offset by the chart search_type.
+func createTrendSearchType(id string, chart map[string]interface{}) map[string]interface{} {
+	trend := make(map[string]interface{}, len(chart))
+	for k, v := range chart {
+		trend[k] = v
+	}
+	trend["id"] = id
+	trend["name"] = "trend"
+	trend["timerange"] = map[string]interface{}{
+		"type":   "offset",
+		"source": "search_type",
+		"id":     chart["id"],
+	}
+	return trend
+}
+
 // createSearchTypeFromWidgetConfig converts a widget config to a search_type (pivot query).
 func createSearchTypeFromWidgetConfig(id string, config map[string]interface{}, widgetTimerange interface{}, defaultTimerange map[string]interface{}, streams []interface{}, widgetQuery map[string]interface{}) map[string]interface{} {
 	if streams == nil {
@@ -343,6 +427,15 @@ func convertSeries(series map[string]interface{}) map[string]interface{} {
 		fnType, field := parseFunction(fn)
 		result["type"] = fnType
 		result["id"] = fn
+		// percentile(field,95) has the percentile after the field
+		if fnType == "percentile" {
+			if i := strings.LastIndex(field, ","); i >= 0 {
+				if p, err := strconv.ParseFloat(field[i+1:], 64); err == nil {
+					result["percentile"] = p
+					field = field[:i]
+				}
+			}
+		}
 		if field != "" {
 			result["field"] = field
 		} else {
@@ -28,6 +28,17 @@ func update(d *schema.ResourceData, m interface{}) error {
 		return errors.New("dashboard state is empty")
 	}
 
+	parameters, err := expandParameters(data[keyParameter])
+	if err != nil {
+		return err
+	}
+	globalOverride, err := expandOverride(data[keyOverride])
+	if err != nil {
+		return err
+	}
+	delete(data, keyParameter)
+	delete(data, keyOverride)
+
 	defaultTimerange := getDefaultTimerange()
 	queries := make([]interface{}, 0, len(stateMap))
 
@@ -43,7 +54,14 @@ func update(d *schema.ResourceData, m interface{}) error {
 		queryString, _ := state[keyQueryString].(string)
 		delete(state, keyQueryString)
 
-		query, widgetMapping, err := generateQueryFromWidgets(stateID, queryString, widgets, defaultTimerange)
+		// Per-tab override takes precedence over the dashboard wide override
+		tabOverride, err := expandOverride(state[keyOverride])
+		if err != nil {
+			return fmt.Errorf("tab %s: %w", stateID, err)
+		}
+		delete(state, keyOverride)
+
+		query, widgetMapping, err := generateQueryFromWidgets(stateID, queryString, widgets, defaultTimerange, globalOverride.merge(tabOverride))
 		if err != nil {
 			return fmt.Errorf("failed to generate search query for tab %s: %w", stateID, err)
 		}
@@ -51,7 +69,7 @@ func update(d *schema.ResourceData, m interface{}) error {
 		applyWidgetMappingToState(state, widgetMapping)
 	}
 
-	searchData := buildSearchObject(queries)
+	searchData := buildSearchObject(queries, parameters)
 
 	// Create a new search (Graylog creates a new search on each dashboard update)
 	searchResp, _, err := cl.ViewSearch.Create(ctx, searchData)
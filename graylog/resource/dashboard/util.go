@@ -66,6 +66,13 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 				}
 			}
 		}
+		if list, ok := stateItem[keyOverride].([]interface{}); ok && len(list) > 0 {
+			if override, ok := list[0].(map[string]interface{}); ok {
+				if set, ok := override[keyStreams].(*schema.Set); ok {
+					override[keyStreams] = set.List()
+				}
+			}
+		}
 
 		// deep copy state to avoid mutating ResourceData during API conversion
 		state, err := deepCopyMap(stateItem)
@@ -101,8 +108,24 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 		}
 		state["titles"] = titles
 
-		// Process widgets
 		widgets := state[keyWidgets].([]interface{})
+
+		// Compute the positions of widgets which don't have user supplied positions
+		layout, _ := state[keyLayout].(string)
+		delete(state, keyLayout)
+		if layout == layoutAuto {
+			fixed, ok := state[keyPositions].(map[string]interface{})
+			if !ok {
+				fixed = map[string]interface{}{}
+			}
+			positions, err := expandLayout(widgets, fixed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute the layout of tab %s: %w", stateID, err)
+			}
+			state[keyPositions] = positions
+		}
+
+		// Process widgets
 		for i, a := range widgets {
 			widget := a.(map[string]interface{})
 			if wID, ok := widget[keyWidgetID]; ok {
@@ -111,6 +134,11 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 				}
 				delete(widget, keyWidgetID)
 			}
+			delete(widget, keyWidth)
+			delete(widget, keyHeight)
+			if err := expandTypedWidget(widget); err != nil {
+				return nil, fmt.Errorf("widget %s: %w", widget["id"], err)
+			}
 			if err := convert.JSONToData(widget, keyConfig, keyTimerange); err != nil {
 				return nil, err
 			}
@@ -165,6 +193,7 @@ func setDataToResourceData(d *schema.ResourceData, data map[string]interface{})
 
 	// Determine output order: match stored state IDs if available, else sort alphabetically
 	idOrder := getStateIDOrder(d, stateMap)
+	stored := getStoredAttributes(d)
 
 	statesList := make([]interface{}, 0, len(stateMap))
 	for _, stateID := range idOrder {
@@ -174,7 +203,7 @@ func setDataToResourceData(d *schema.ResourceData, data map[string]interface{})
 		}
 		state := sv.(map[string]interface{})
 
-		cleanState, err := flattenState(stateID, state)
+		cleanState, err := flattenState(stateID, state, stored)
 		if err != nil {
 			return err
 		}
@@ -184,7 +213,7 @@ func setDataToResourceData(d *schema.ResourceData, data map[string]interface{})
 	data[keyState] = statesList
 
 	for _, k := range []string{
-		"title", "description", "summary", "type", "search_id", "owner", "created_at",
+		"title", "description", "summary", "type", "search_id", "owner", "created_at", keyParameter,
 	} {
 		if v, ok := data[k]; ok {
 			if err := d.Set(k, v); err != nil {
@@ -257,14 +286,75 @@ func getStateIDOrder(d *schema.ResourceData, stateMap map[string]interface{}) []
 	return ids
 }
 
+// getTypedWidgetIDs returns the IDs of the widgets which are configured with typed widget blocks in the stored state.
+func getTypedWidgetIDs(d *schema.ResourceData) map[string]bool {
+	ids := map[string]bool{}
+	stored, _ := d.Get(keyState).([]interface{})
+	for _, s := range stored {
+		sm, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		widgets, _ := sm[keyWidgets].([]interface{})
+		for _, w := range widgets {
+			widget, ok := w.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := widget[keyWidgetID].(string)
+			if id == "" {
+				continue
+			}
+			if name, _, err := getTypedWidgetBlock(widget); err == nil && name != "" {
+				ids[id] = true
+			}
+		}
+	}
+	return ids
+}
+
+// storedAttributes are the attributes which aren't returned by the API and are kept from the stored state.
+type storedAttributes struct {
+	typedWidgets        map[string]bool
+	widgetSizes         map[string][2]int
+	layouts             map[string]string
+	overrides           map[string]map[string]interface{}
+	overrideQueryString string
+}
+
+func getStoredAttributes(d *schema.ResourceData) storedAttributes {
+	return storedAttributes{
+		typedWidgets:        getTypedWidgetIDs(d),
+		widgetSizes:         getWidgetSizes(d),
+		layouts:             getLayouts(d),
+		overrides:           getStoredOverrides(d),
+		overrideQueryString: getStoredOverrideQueryString(d),
+	}
+}
+
 // flattenState converts a single API state entry to Terraform-compatible format.
-func flattenState(stateID string, state map[string]interface{}) (map[string]interface{}, error) {
+// Widgets configured with typed widget blocks in the stored state are converted to typed widget blocks instead of config if possible.
+func flattenState(stateID string, state map[string]interface{}, stored storedAttributes) (map[string]interface{}, error) {
+	positions, _ := state[keyPositions].(map[string]interface{})
 	widgets := state[keyWidgets].([]interface{})
 	for i, a := range widgets {
 		widget := a.(map[string]interface{})
 		if id, ok := widget["id"]; ok {
 			widget[keyWidgetID] = id
 		}
+		id, _ := widget["id"].(string)
+		flattenWidgetSize(widget, stored.widgetSizes[id], positions)
+		typedBlock := ""
+		if stored.typedWidgets[id] {
+			if config, ok := widget[keyConfig].(map[string]interface{}); ok {
+				wType, _ := widget["type"].(string)
+				if name, block, ok := flattenTypedWidget(wType, config); ok {
+					typedBlock = name
+					widget[name] = []interface{}{block}
+					delete(widget, keyConfig)
+				}
+			}
+		}
 		if err := convert.DataToJSON(widget, keyConfig, keyTimerange); err != nil {
 			return nil, err
 		}
@@ -289,7 +379,7 @@ func flattenState(stateID string, state map[string]interface{}) (map[string]inte
 		}
 		for k := range widget {
 			switch k {
-			case keyWidgetID, "type", keyConfig, keyTimerange, "query", "streams":
+			case keyWidgetID, "type", keyConfig, keyTimerange, "query", "streams", keyWidth, keyHeight, typedBlock:
 			default:
 				delete(widget, k)
 			}
@@ -307,6 +397,10 @@ func flattenState(stateID string, state map[string]interface{}) (map[string]inte
 	cleanState := map[string]interface{}{
 		keyWidgets: widgets,
 		keyID:      stateID,
+		keyLayout:  layoutManual,
+	}
+	if layout := stored.layouts[stateID]; layout != "" {
+		cleanState[keyLayout] = layout
 	}
 
 	// Handle titles from API response and extract tab_title
@@ -338,11 +432,26 @@ func flattenState(stateID string, state map[string]interface{}) (map[string]inte
 		cleanState["titles"] = "{}"
 	}
 
-	// Handle query_string from search injection
+	// Handle query_string from search injection.
+	// If the query has no persistent filter, query.query_string is used unless it's set by an override.
+	override, _ := state[keyOverride].(map[string]interface{})
+	storedOverride, hasOverride := stored.overrides[stateID]
+	overrideQueryString, _ := storedOverride["query_string"].(string)
+	if overrideQueryString == "" {
+		overrideQueryString = stored.overrideQueryString
+	}
+	cleanState[keyQueryString] = ""
 	if qs, ok := state[keyQueryString].(string); ok {
 		cleanState[keyQueryString] = qs
-	} else {
-		cleanState[keyQueryString] = ""
+	} else if qs, ok := override["query_string"].(string); ok && overrideQueryString == "" {
+		cleanState[keyQueryString] = qs
+	}
+	if hasOverride {
+		o, err := flattenOverride(override, storedOverride)
+		if err != nil {
+			return nil, err
+		}
+		cleanState[keyOverride] = o
 	}
 
 	if v, ok := state[keyWidgetMapping]; ok {
@@ -450,8 +559,8 @@ func injectStreamsFromSearch(viewData, searchData map[string]interface{}) {
 	}
 }
 
-// injectQueryFromSearch maps per-tab query strings from search queries back to view state entries.
-// It reads from the persistent filter field (preferred) or falls back to query.query_string.
+// injectQueryFromSearch maps per-tab query strings from the persistent filters of search queries back to view state entries.
+// Queries without a query_string filter are resolved in flattenState because query.query_string may be set by an override.
 func injectQueryFromSearch(viewData, searchData map[string]interface{}) {
 	queryStrings := map[string]string{}
 	if queries, ok := searchData["queries"].([]interface{}); ok {
@@ -464,33 +573,15 @@ func injectQueryFromSearch(viewData, searchData map[string]interface{}) {
 			if !ok || qID == "" {
 				continue
 			}
-			// Prefer persistent filter over query.query_string
-			if filterObj, ok := query["filter"].(map[string]interface{}); ok {
-				// Direct query_string filter
-				if fq, ok := filterObj["query"].(string); ok && fq != "" {
-					queryStrings[qID] = fq
-					continue
-				}
-				// Compound "or" filter wrapping a query_string filter
-				if filters, ok := filterObj["filters"].([]interface{}); ok {
-					for _, f := range filters {
-						if fm, ok := f.(map[string]interface{}); ok {
-							if ft, _ := fm["type"].(string); ft == "query_string" {
-								if fq, ok := fm["query"].(string); ok && fq != "" {
-									queryStrings[qID] = fq
-									break
-								}
-							}
-						}
-					}
-					if _, found := queryStrings[qID]; found {
-						continue
-					}
-				}
+			filterObj, ok := query["filter"].(map[string]interface{})
+			if !ok {
+				continue
 			}
-			if qObj, ok := query["query"].(map[string]interface{}); ok {
-				if qs, ok := qObj["query_string"].(string); ok {
-					queryStrings[qID] = qs
+			// The query_string filter is either the filter itself or wrapped by "or" and "and" filters
+			for _, f := range findFilters(filterObj, "query_string") {
+				if fq, ok := f["query"].(string); ok && fq != "" {
+					queryStrings[qID] = fq
+					break
 				}
 			}
 		}
@@ -0,0 +1,531 @@
+package dashboard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultGroupLimit = 15
+
+// defaultEventFields are the columns of an events list widget when fields aren't set.
+var defaultEventFields = []interface{}{"timestamp", "message", "priority", "alert", "event_definition"}
+
+// intervalUnits maps interval suffixes to the units of the widget API.
+var intervalUnits = map[string]string{
+	"s": "seconds",
+	"m": "minutes",
+	"h": "hours",
+	"d": "days",
+	"w": "weeks",
+	"M": "months",
+}
+
+// typedWidgetBlockNames returns the typed widget block names in a stable order.
+func typedWidgetBlockNames() []string {
+	names := make([]string, 0, len(widgetKinds))
+	for name := range widgetKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getTypedWidgetBlock returns the name and the content of the typed widget block set in the widget.
+func getTypedWidgetBlock(widget map[string]interface{}) (string, map[string]interface{}, error) {
+	name := ""
+	var block map[string]interface{}
+	for _, k := range typedWidgetBlockNames() {
+		list, ok := widget[k].([]interface{})
+		if !ok || len(list) == 0 {
+			continue
+		}
+		if name != "" {
+			return "", nil, fmt.Errorf("only one of %s and %s can be set in a widget", name, k)
+		}
+		name = k
+		block, _ = list[0].(map[string]interface{})
+		if block == nil {
+			block = map[string]interface{}{}
+		}
+	}
+	return name, block, nil
+}
+
+// expandTypedWidget renders a typed widget block to the widget type and config.
+// The typed widget blocks are removed from the widget.
+func expandTypedWidget(widget map[string]interface{}) error {
+	name, block, err := getTypedWidgetBlock(widget)
+	if err != nil {
+		return err
+	}
+	for k := range widgetKinds {
+		delete(widget, k)
+	}
+
+	rawConfig := widget[keyConfig]
+	hasConfig := rawConfig != nil
+	if s, ok := rawConfig.(string); ok && s == "" {
+		hasConfig = false
+	}
+	if name == "" {
+		if !hasConfig {
+			return errors.New("either config or a typed widget block must be set")
+		}
+		if t, _ := widget["type"].(string); t == "" {
+			return errors.New("type must be set if config is set")
+		}
+		return nil
+	}
+	if hasConfig {
+		return fmt.Errorf("config can't be set together with %s", name)
+	}
+
+	kind := widgetKinds[name]
+	if t, _ := widget["type"].(string); t != "" && t != kind.widgetType {
+		return fmt.Errorf("type must be %s or empty if %s is set", kind.widgetType, name)
+	}
+	widget["type"] = kind.widgetType
+
+	var config map[string]interface{}
+	if kind.widgetType == widgetTypeEvents {
+		config = expandEventsConfig(block)
+	} else {
+		config, err = expandAggregationConfig(kind.visualization, block)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	widget[keyConfig] = config
+	return nil
+}
+
+// validateTypedWidgetBlock validates the groupings, series and sort of a typed widget block.
+func validateTypedWidgetBlock(name string, block map[string]interface{}) error {
+	kind := widgetKinds[name]
+	if kind.widgetType != widgetTypeAggregation {
+		return nil
+	}
+	if _, err := expandAggregationConfig(kind.visualization, block); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+func expandEventsConfig(block map[string]interface{}) map[string]interface{} {
+	fields, _ := block["fields"].([]interface{})
+	if len(fields) == 0 {
+		fields = defaultEventFields
+	}
+	return map[string]interface{}{
+		"fields":  fields,
+		"filters": []interface{}{},
+		"mode":    "List",
+		"sort": map[string]interface{}{
+			"type":      "pivot",
+			"field":     block["sort_field"],
+			"direction": block["sort_direction"],
+		},
+	}
+}
+
+func expandAggregationConfig(visualization string, block map[string]interface{}) (map[string]interface{}, error) {
+	groupFields := map[string]struct{}{}
+	pivots := map[string][]interface{}{}
+	for _, k := range []string{"row_group", "column_group"} {
+		groups, _ := block[k].([]interface{})
+		pivots[k] = make([]interface{}, 0, len(groups))
+		for i, a := range groups {
+			group, _ := a.(map[string]interface{})
+			pivot, err := expandGroup(group)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%d: %w", k, i, err)
+			}
+			for _, f := range pivot["fields"].([]interface{}) {
+				groupFields[f.(string)] = struct{}{}
+			}
+			pivots[k] = append(pivots[k], pivot)
+		}
+	}
+
+	rawSeries, _ := block["series"].([]interface{})
+	series := make([]interface{}, 0, len(rawSeries))
+	functions := map[string]struct{}{}
+	for i, a := range rawSeries {
+		s, _ := a.(map[string]interface{})
+		fn, err := seriesFunction(s)
+		if err != nil {
+			return nil, fmt.Errorf("series.%d: %w", i, err)
+		}
+		functions[fn] = struct{}{}
+		var name interface{}
+		if n, _ := s["name"].(string); n != "" {
+			name = n
+		}
+		series = append(series, map[string]interface{}{
+			"function": fn,
+			"config":   map[string]interface{}{"name": name},
+		})
+	}
+
+	rawSort, _ := block["sort"].([]interface{})
+	sorts := make([]interface{}, 0, len(rawSort))
+	for i, a := range rawSort {
+		s, _ := a.(map[string]interface{})
+		field, _ := s["field"].(string)
+		switch s["type"] {
+		case "series":
+			if _, ok := functions[field]; !ok {
+				return nil, fmt.Errorf("sort.%d: field %q must be one of the series functions such as count() or avg(took_ms)", i, field)
+			}
+		case "pivot":
+			if _, ok := groupFields[field]; !ok {
+				return nil, fmt.Errorf("sort.%d: field %q must be one of the fields of row_group or column_group", i, field)
+			}
+		}
+		sorts = append(sorts, map[string]interface{}{
+			"type":      s["type"],
+			"field":     field,
+			"direction": s["direction"],
+		})
+	}
+
+	switch visualization {
+	case "heatmap":
+		if len(pivots["row_group"]) == 0 || len(pivots["column_group"]) == 0 {
+			return nil, errors.New("heatmap requires both row_group and column_group")
+		}
+	case "map":
+		if len(pivots["row_group"]) == 0 {
+			return nil, errors.New("world_map requires a row_group on a geo point field")
+		}
+	}
+
+	rollup := true
+	if v, ok := block["rollup"].(bool); ok {
+		rollup = v
+	}
+
+	return map[string]interface{}{
+		"row_pivots":           pivots["row_group"],
+		"column_pivots":        pivots["column_group"],
+		"series":               series,
+		"sort":                 sorts,
+		"rollup":               rollup,
+		"visualization":        visualization,
+		"visualization_config": expandVisualizationConfig(visualization, block),
+		"event_annotation":     false,
+	}, nil
+}
+
+func expandGroup(group map[string]interface{}) (map[string]interface{}, error) {
+	fields, _ := group["fields"].([]interface{})
+	if len(fields) == 0 {
+		return nil, errors.New("fields is required")
+	}
+	groupType, _ := group["type"].(string)
+	if groupType == "" {
+		groupType = "values"
+	}
+	pivot := map[string]interface{}{
+		"type":   groupType,
+		"fields": fields,
+	}
+	if groupType == "values" {
+		limit := intValue(group["limit"])
+		if limit == 0 {
+			limit = defaultGroupLimit
+		}
+		pivot[keyConfig] = map[string]interface{}{"limit": limit}
+		return pivot, nil
+	}
+
+	interval, _ := group["interval"].(string)
+	if interval == "" || interval == "auto" {
+		scaling, _ := group["scaling"].(float64)
+		if scaling == 0 {
+			scaling = 1
+		}
+		pivot[keyConfig] = map[string]interface{}{
+			"interval": map[string]interface{}{"type": "auto", "scaling": scaling},
+		}
+		return pivot, nil
+	}
+	if !intervalPattern.MatchString(interval) {
+		return nil, fmt.Errorf("interval %q is invalid", interval)
+	}
+	value, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil {
+		return nil, fmt.Errorf("interval %q is invalid: %w", interval, err)
+	}
+	pivot[keyConfig] = map[string]interface{}{
+		"interval": map[string]interface{}{
+			"type":  "timeunit",
+			"value": value,
+			"unit":  intervalUnits[interval[len(interval)-1:]],
+		},
+	}
+	return pivot, nil
+}
+
+// seriesFunction renders a series block to a function string such as count(), avg(took_ms) and percentile(took_ms,95).
+func seriesFunction(s map[string]interface{}) (string, error) {
+	fn, _ := s["function"].(string)
+	field, _ := s["field"].(string)
+	percentile, _ := s["percentile"].(float64)
+	switch fn {
+	case "":
+		return "", errors.New("function is required")
+	case "count", "percentage":
+	default:
+		if field == "" {
+			return "", fmt.Errorf("field is required for %s", fn)
+		}
+	}
+	if fn == "percentile" {
+		if percentile <= 0 {
+			return "", errors.New("percentile is required for percentile")
+		}
+		return fmt.Sprintf("percentile(%s,%s)", field, strconv.FormatFloat(percentile, 'f', -1, 64)), nil
+	}
+	if percentile != 0 {
+		return "", fmt.Errorf("percentile can't be set for %s", fn)
+	}
+	return fn + "(" + field + ")", nil
+}
+
+func expandVisualizationConfig(visualization string, block map[string]interface{}) interface{} {
+	switch visualization {
+	case "bar":
+		return map[string]interface{}{
+			"barmode":   block["barmode"],
+			"axis_type": block["axis_type"],
+		}
+	case "line", "area":
+		return map[string]interface{}{
+			"interpolation": block["interpolation"],
+			"axis_type":     block["axis_type"],
+		}
+	case "numeric":
+		return map[string]interface{}{
+			"trend":            block["trend"],
+			"trend_preference": block["trend_preference"],
+		}
+	case "heatmap":
+		return map[string]interface{}{
+			"color_scale":   block["color_scale"],
+			"reverse_scale": block["reverse_scale"],
+			"auto_scale":    block["auto_scale"],
+		}
+	case "map":
+		return map[string]interface{}{
+			"viewport": map[string]interface{}{
+				"center": []interface{}{block["center_latitude"], block["center_longitude"]},
+				"zoom":   block["zoom"],
+			},
+		}
+	}
+	return nil
+}
+
+// flattenTypedWidget converts a widget config returned by the API to the typed widget block.
+// ok is false if the config can't be represented by any typed widget block.
+func flattenTypedWidget(widgetType string, config map[string]interface{}) (string, map[string]interface{}, bool) {
+	if widgetType == widgetTypeEvents {
+		return "events_list", flattenEventsConfig(config), true
+	}
+	if widgetType != widgetTypeAggregation {
+		return "", nil, false
+	}
+	visualization, _ := config["visualization"].(string)
+	name := ""
+	for _, k := range typedWidgetBlockNames() {
+		if widgetKinds[k].visualization == visualization && widgetKinds[k].widgetType == widgetTypeAggregation {
+			name = k
+			break
+		}
+	}
+	if name == "" {
+		return "", nil, false
+	}
+
+	block := map[string]interface{}{}
+	for attr, key := range map[string]string{"row_group": "row_pivots", "column_group": "column_pivots"} {
+		pivots, _ := config[key].([]interface{})
+		groups := make([]interface{}, 0, len(pivots))
+		for _, a := range pivots {
+			pivot, ok := a.(map[string]interface{})
+			if !ok {
+				return "", nil, false
+			}
+			group, ok := flattenGroup(pivot)
+			if !ok {
+				return "", nil, false
+			}
+			groups = append(groups, group)
+		}
+		block[attr] = groups
+	}
+
+	rawSeries, _ := config["series"].([]interface{})
+	series := make([]interface{}, 0, len(rawSeries))
+	for _, a := range rawSeries {
+		s, ok := a.(map[string]interface{})
+		if !ok {
+			return "", nil, false
+		}
+		fn, _ := s["function"].(string)
+		fnType, field := parseFunction(fn)
+		elem := map[string]interface{}{"function": fnType, "field": field, "percentile": 0.0, "name": ""}
+		if fnType == "percentile" {
+			if i := strings.LastIndex(field, ","); i >= 0 {
+				p, err := strconv.ParseFloat(field[i+1:], 64)
+				if err != nil {
+					return "", nil, false
+				}
+				elem["field"] = field[:i]
+				elem["percentile"] = p
+			}
+		}
+		if cfg, ok := s[keyConfig].(map[string]interface{}); ok {
+			if n, ok := cfg["name"].(string); ok {
+				elem["name"] = n
+			}
+		}
+		series = append(series, elem)
+	}
+	block["series"] = series
+
+	rawSort, _ := config["sort"].([]interface{})
+	sorts := make([]interface{}, 0, len(rawSort))
+	for _, a := range rawSort {
+		s, ok := a.(map[string]interface{})
+		if !ok {
+			return "", nil, false
+		}
+		sorts = append(sorts, map[string]interface{}{
+			"type":      s["type"],
+			"field":     s["field"],
+			"direction": s["direction"],
+		})
+	}
+	block["sort"] = sorts
+
+	vc, _ := config["visualization_config"].(map[string]interface{})
+	switch name {
+	case "table":
+		rollup, ok := config["rollup"].(bool)
+		block["rollup"] = rollup || !ok
+	case "bar_chart":
+		block["barmode"] = stringOr(vc, "barmode", "group")
+		block["axis_type"] = stringOr(vc, "axis_type", "linear")
+	case "line_chart", "area_chart":
+		block["interpolation"] = stringOr(vc, "interpolation", "linear")
+		block["axis_type"] = stringOr(vc, "axis_type", "linear")
+	case "numeric":
+		if len(block["row_group"].([]interface{})) != 0 || len(block["column_group"].([]interface{})) != 0 || len(sorts) != 0 {
+			return "", nil, false
+		}
+		delete(block, "row_group")
+		delete(block, "column_group")
+		delete(block, "sort")
+		block["trend"], _ = vc["trend"].(bool)
+		block["trend_preference"] = stringOr(vc, "trend_preference", "NEUTRAL")
+	case "heatmap":
+		block["color_scale"] = stringOr(vc, "color_scale", "Viridis")
+		block["reverse_scale"], _ = vc["reverse_scale"].(bool)
+		autoScale, ok := vc["auto_scale"].(bool)
+		block["auto_scale"] = autoScale || !ok
+	case "world_map":
+		block["zoom"] = 1
+		block["center_latitude"] = 0.0
+		block["center_longitude"] = 0.0
+		if viewport, ok := vc["viewport"].(map[string]interface{}); ok {
+			if zoom, ok := viewport["zoom"].(float64); ok {
+				block["zoom"] = int(zoom)
+			}
+			if center, ok := viewport["center"].([]interface{}); ok && len(center) == 2 {
+				block["center_latitude"], _ = center[0].(float64)
+				block["center_longitude"], _ = center[1].(float64)
+			}
+		}
+	}
+	return name, block, true
+}
+
+func flattenEventsConfig(config map[string]interface{}) map[string]interface{} {
+	block := map[string]interface{}{
+		"fields":         config["fields"],
+		"sort_field":     "timestamp",
+		"sort_direction": "Descending",
+	}
+	if s, ok := config["sort"].(map[string]interface{}); ok {
+		block["sort_field"] = stringOr(s, "field", "timestamp")
+		block["sort_direction"] = stringOr(s, "direction", "Descending")
+	}
+	return block
+}
+
+func flattenGroup(pivot map[string]interface{}) (map[string]interface{}, bool) {
+	groupType, _ := pivot["type"].(string)
+	group := map[string]interface{}{
+		"type":     groupType,
+		"fields":   pivot["fields"],
+		"limit":    defaultGroupLimit,
+		"interval": "auto",
+		"scaling":  1.0,
+	}
+	cfg, _ := pivot[keyConfig].(map[string]interface{})
+	switch groupType {
+	case "values":
+		if limit, ok := cfg["limit"].(float64); ok {
+			group["limit"] = int(limit)
+		}
+	case "time":
+		interval, _ := cfg["interval"].(map[string]interface{})
+		switch interval["type"] {
+		case "auto", nil:
+			if scaling, ok := interval["scaling"].(float64); ok {
+				group["scaling"] = scaling
+			}
+		case "timeunit":
+			value, _ := interval["value"].(float64)
+			unit, _ := interval["unit"].(string)
+			suffix := ""
+			for k, v := range intervalUnits {
+				if v == unit {
+					suffix = k
+				}
+			}
+			if suffix == "" || value < 1 {
+				return nil, false
+			}
+			group["interval"] = strconv.Itoa(int(value)) + suffix
+		default:
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	return group, true
+}
+
+// intValue returns v as int. Numbers are float64 after the state is copied through JSON.
+func intValue(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+func stringOr(m map[string]interface{}, key, def string) string {
+	if s, ok := m[key].(string); ok && s != "" {
+		return s
+	}
+	return def
+}
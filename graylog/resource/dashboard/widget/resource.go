@@ -2,6 +2,7 @@ package widget
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -16,7 +17,7 @@ func Resource() *schema.Resource {
 		StateUpgraders: stateUpgraders,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: util.GenStateFunc("dashboard_id", "widget_id"),
+			StateContext: importer.GenStateFunc(importer.Parent("dashboard_id", importer.Dashboard), importer.ID("widget_id")),
 		},
 
 		Schema: map[string]*schema.Schema{
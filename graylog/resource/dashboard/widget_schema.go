@@ -0,0 +1,280 @@
+package dashboard
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// widgetKind describes a typed widget block and the widget it's rendered to.
+type widgetKind struct {
+	widgetType    string
+	visualization string
+}
+
+// widgetKinds maps typed widget block names to Graylog widget types and visualizations.
+var widgetKinds = map[string]widgetKind{
+	"table":       {widgetType: widgetTypeAggregation, visualization: "table"},
+	"bar_chart":   {widgetType: widgetTypeAggregation, visualization: "bar"},
+	"line_chart":  {widgetType: widgetTypeAggregation, visualization: "line"},
+	"area_chart":  {widgetType: widgetTypeAggregation, visualization: "area"},
+	"pie_chart":   {widgetType: widgetTypeAggregation, visualization: "pie"},
+	"numeric":     {widgetType: widgetTypeAggregation, visualization: "numeric"},
+	"heatmap":     {widgetType: widgetTypeAggregation, visualization: "heatmap"},
+	"world_map":   {widgetType: widgetTypeAggregation, visualization: "map"},
+	"events_list": {widgetType: widgetTypeEvents},
+}
+
+const (
+	widgetTypeAggregation = "aggregation"
+	widgetTypeMessages    = "messages"
+	widgetTypeEvents      = "events"
+)
+
+var seriesFunctions = []string{
+	"avg", "card", "count", "latest", "max", "min", "percentage",
+	"percentile", "stddev", "sum", "sumofsquares", "variance",
+}
+
+var colorScales = []string{
+	"Blackbody", "Bluered", "Blues", "Cividis", "Earth", "Electric", "Greens", "Greys",
+	"Hot", "Jet", "Picnic", "Portland", "Rainbow", "RdBu", "Reds", "Viridis", "YlGnBu", "YlOrRd",
+}
+
+// intervalPattern matches "auto" or a time unit interval such as "5m", "1h" and "1d".
+var intervalPattern = regexp.MustCompile(`^(auto|[1-9][0-9]*[smhdwM])$`)
+
+var schemaGroup = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "values",
+				ValidateFunc: validation.StringInSlice([]string{"values", "time"}, false),
+			},
+			"fields": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// values only
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultGroupLimit,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			// time only
+			"interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "auto",
+				ValidateFunc: validation.StringMatch(intervalPattern, `interval must be "auto" or a number followed by one of s, m, h, d, w and M`),
+			},
+			"scaling": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      1.0,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+		},
+	},
+}
+
+func schemaSeries(maxItems int) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		MaxItems: maxItems,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"function": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(seriesFunctions, false),
+				},
+				"field": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"percentile": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					ValidateFunc: validation.FloatBetween(0, 100),
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+var schemaSort = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "series",
+				ValidateFunc: validation.StringInSlice([]string{"series", "pivot"}, false),
+			},
+			"field": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Descending",
+				ValidateFunc: validation.StringInSlice([]string{"Ascending", "Descending"}, false),
+			},
+		},
+	},
+}
+
+var schemaAxisType = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	Default:      "linear",
+	ValidateFunc: validation.StringInSlice([]string{"linear", "logarithmic"}, false),
+}
+
+var schemaInterpolation = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	Default:      "linear",
+	ValidateFunc: validation.StringInSlice([]string{"linear", "step-after", "spline"}, false),
+}
+
+// typedWidgetBlock returns a single typed widget block with the given attributes.
+func typedWidgetBlock(attrs map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem:     &schema.Resource{Schema: attrs},
+	}
+}
+
+// pivotAttributes returns the row/column groupings, series and sort shared by aggregation widgets.
+func pivotAttributes(extra map[string]*schema.Schema) map[string]*schema.Schema {
+	attrs := map[string]*schema.Schema{
+		"row_group":    schemaGroup,
+		"column_group": schemaGroup,
+		"series":       schemaSeries(0),
+		"sort":         schemaSort,
+	}
+	for k, v := range extra {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// typedWidgetSchemas returns the typed widget blocks of the widgets block.
+func typedWidgetSchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"table": typedWidgetBlock(pivotAttributes(map[string]*schema.Schema{
+			"rollup": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		})),
+		"bar_chart": typedWidgetBlock(pivotAttributes(map[string]*schema.Schema{
+			"axis_type": schemaAxisType,
+			"barmode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "group",
+				ValidateFunc: validation.StringInSlice([]string{"group", "stack", "relative", "overlay"}, false),
+			},
+		})),
+		"line_chart": typedWidgetBlock(pivotAttributes(map[string]*schema.Schema{
+			"axis_type":     schemaAxisType,
+			"interpolation": schemaInterpolation,
+		})),
+		"area_chart": typedWidgetBlock(pivotAttributes(map[string]*schema.Schema{
+			"axis_type":     schemaAxisType,
+			"interpolation": schemaInterpolation,
+		})),
+		"pie_chart": typedWidgetBlock(pivotAttributes(nil)),
+		"numeric": typedWidgetBlock(map[string]*schema.Schema{
+			"series": schemaSeries(1),
+			"trend": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"trend_preference": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NEUTRAL",
+				ValidateFunc: validation.StringInSlice([]string{"LOWER", "NEUTRAL", "HIGHER"}, false),
+			},
+		}),
+		"heatmap": typedWidgetBlock(pivotAttributes(map[string]*schema.Schema{
+			"series": schemaSeries(1),
+			"color_scale": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Viridis",
+				ValidateFunc: validation.StringInSlice(colorScales, false),
+			},
+			"reverse_scale": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"auto_scale": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		})),
+		"world_map": typedWidgetBlock(pivotAttributes(map[string]*schema.Schema{
+			"zoom": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(0, 20),
+			},
+			"center_latitude": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatBetween(-90, 90),
+			},
+			"center_longitude": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatBetween(-180, 180),
+			},
+		})),
+		"events_list": typedWidgetBlock(map[string]*schema.Schema{
+			"fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"sort_field": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "timestamp",
+			},
+			"sort_direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Descending",
+				ValidateFunc: validation.StringInSlice([]string{"Ascending", "Descending"}, false),
+			},
+		}),
+	}
+}
@@ -0,0 +1,216 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandTypedWidgetRoundTrip(t *testing.T) {
+	block := map[string]interface{}{
+		"row_group": []interface{}{
+			map[string]interface{}{"type": "time", "fields": []interface{}{"timestamp"}, "limit": 15.0, "interval": "5m", "scaling": 1.0},
+		},
+		"column_group": []interface{}{
+			map[string]interface{}{"type": "values", "fields": []interface{}{"source"}, "limit": 5.0, "interval": "auto", "scaling": 1.0},
+		},
+		"series": []interface{}{
+			map[string]interface{}{"function": "count", "field": "", "percentile": 0.0, "name": ""},
+			map[string]interface{}{"function": "percentile", "field": "took_ms", "percentile": 95.0, "name": "p95"},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"type": "series", "field": "count()", "direction": "Descending"},
+		},
+		"barmode":   "stack",
+		"axis_type": "logarithmic",
+	}
+	widget := map[string]interface{}{
+		"id":        "widget-1",
+		"bar_chart": []interface{}{block},
+	}
+	if err := expandTypedWidget(widget); err != nil {
+		t.Fatalf("expandTypedWidget returned error: %v", err)
+	}
+	if widget["type"] != widgetTypeAggregation {
+		t.Fatalf("type = %v, want %s", widget["type"], widgetTypeAggregation)
+	}
+	if _, ok := widget["bar_chart"]; ok {
+		t.Fatal("bar_chart should be removed from the widget")
+	}
+	config := widget[keyConfig].(map[string]interface{})
+	if config["visualization"] != "bar" {
+		t.Fatalf("visualization = %v, want bar", config["visualization"])
+	}
+	series := config["series"].([]interface{})
+	if fn := series[1].(map[string]interface{})["function"]; fn != "percentile(took_ms,95)" {
+		t.Fatalf("series function = %v, want percentile(took_ms,95)", fn)
+	}
+	rowPivot := config["row_pivots"].([]interface{})[0].(map[string]interface{})
+	interval := rowPivot[keyConfig].(map[string]interface{})["interval"].(map[string]interface{})
+	if interval["type"] != "timeunit" || interval["value"] != 5 || interval["unit"] != "minutes" {
+		t.Fatalf("unexpected interval: %v", interval)
+	}
+
+	// the API returns numbers as float64
+	config, err := deepCopyMap(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, flattened, ok := flattenTypedWidget(widgetTypeAggregation, config)
+	if !ok || name != "bar_chart" {
+		t.Fatalf("flattenTypedWidget = %s, %v", name, ok)
+	}
+	for _, k := range []string{"barmode", "axis_type"} {
+		if flattened[k] != block[k] {
+			t.Fatalf("%s = %v, want %v", k, flattened[k], block[k])
+		}
+	}
+	group := flattened["row_group"].([]interface{})[0].(map[string]interface{})
+	if group["interval"] != "5m" {
+		t.Fatalf("row_group interval = %v, want 5m", group["interval"])
+	}
+	group = flattened["column_group"].([]interface{})[0].(map[string]interface{})
+	if group["limit"] != 5 {
+		t.Fatalf("column_group limit = %v, want 5", group["limit"])
+	}
+	s := flattened["series"].([]interface{})[1].(map[string]interface{})
+	if s["function"] != "percentile" || s["field"] != "took_ms" || s["percentile"] != 95.0 || s["name"] != "p95" {
+		t.Fatalf("unexpected series: %v", s)
+	}
+}
+
+func TestExpandTypedWidgetValidation(t *testing.T) {
+	data := []struct {
+		title  string
+		widget map[string]interface{}
+		errMsg string
+	}{
+		{
+			title: "sort by unknown series",
+			widget: map[string]interface{}{
+				"table": []interface{}{map[string]interface{}{
+					"series": []interface{}{map[string]interface{}{"function": "count"}},
+					"sort":   []interface{}{map[string]interface{}{"type": "series", "field": "avg(took_ms)", "direction": "Ascending"}},
+				}},
+			},
+			errMsg: "must be one of the series functions",
+		},
+		{
+			title: "sort by unknown pivot",
+			widget: map[string]interface{}{
+				"table": []interface{}{map[string]interface{}{
+					"series": []interface{}{map[string]interface{}{"function": "count"}},
+					"sort":   []interface{}{map[string]interface{}{"type": "pivot", "field": "source", "direction": "Ascending"}},
+				}},
+			},
+			errMsg: "must be one of the fields of row_group or column_group",
+		},
+		{
+			title: "series without field",
+			widget: map[string]interface{}{
+				"pie_chart": []interface{}{map[string]interface{}{
+					"series": []interface{}{map[string]interface{}{"function": "avg"}},
+				}},
+			},
+			errMsg: "field is required for avg",
+		},
+		{
+			title: "heatmap without column_group",
+			widget: map[string]interface{}{
+				"heatmap": []interface{}{map[string]interface{}{
+					"row_group": []interface{}{map[string]interface{}{"type": "values", "fields": []interface{}{"source"}}},
+					"series":    []interface{}{map[string]interface{}{"function": "count"}},
+				}},
+			},
+			errMsg: "heatmap requires both row_group and column_group",
+		},
+		{
+			title: "config and typed block",
+			widget: map[string]interface{}{
+				keyConfig: `{}`,
+				"numeric": []interface{}{map[string]interface{}{
+					"series": []interface{}{map[string]interface{}{"function": "count"}},
+				}},
+			},
+			errMsg: "config can't be set together with numeric",
+		},
+		{
+			title: "two typed blocks",
+			widget: map[string]interface{}{
+				"numeric":     []interface{}{map[string]interface{}{}},
+				"events_list": []interface{}{map[string]interface{}{}},
+			},
+			errMsg: "only one of",
+		},
+		{
+			title:  "neither config nor typed block",
+			widget: map[string]interface{}{"type": "aggregation"},
+			errMsg: "either config or a typed widget block must be set",
+		},
+		{
+			title:  "config without type",
+			widget: map[string]interface{}{keyConfig: `{}`},
+			errMsg: "type must be set if config is set",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			err := expandTypedWidget(d.widget)
+			if err == nil {
+				t.Fatal("expandTypedWidget should return an error")
+			}
+			if !strings.Contains(err.Error(), d.errMsg) {
+				t.Fatalf("error = %q, want it to contain %q", err.Error(), d.errMsg)
+			}
+		})
+	}
+}
+
+func TestGenerateQueryFromWidgetsTypedWidgets(t *testing.T) {
+	widgets := []interface{}{
+		map[string]interface{}{
+			"id": "numeric-1",
+			"numeric": []interface{}{map[string]interface{}{
+				"series":           []interface{}{map[string]interface{}{"function": "count"}},
+				"trend":            true,
+				"trend_preference": "LOWER",
+			}},
+		},
+		map[string]interface{}{
+			"id": "events-1",
+			"events_list": []interface{}{map[string]interface{}{
+				"sort_field":     "timestamp",
+				"sort_direction": "Ascending",
+			}},
+		},
+	}
+	for _, w := range widgets {
+		if err := expandTypedWidget(w.(map[string]interface{})); err != nil {
+			t.Fatalf("expandTypedWidget returned error: %v", err)
+		}
+	}
+	query, mapping, err := generateQueryFromWidgets("query-1", "", widgets, getDefaultTimerange(), queryOverride{})
+	if err != nil {
+		t.Fatalf("generateQueryFromWidgets returned error: %v", err)
+	}
+	if n := len(mapping["numeric-1"]); n != 2 {
+		t.Fatalf("numeric widget with trend should be mapped to 2 search types, got %d", n)
+	}
+	if n := len(mapping["events-1"]); n != 1 {
+		t.Fatalf("events widget should be mapped to 1 search type, got %d", n)
+	}
+	searchTypes := query["search_types"].([]interface{})
+	if len(searchTypes) != 3 {
+		t.Fatalf("3 search types should be generated, got %d", len(searchTypes))
+	}
+	trend := searchTypes[1].(map[string]interface{})
+	if tr := trend["timerange"].(map[string]interface{}); tr["type"] != "offset" || tr["id"] != mapping["numeric-1"][0] {
+		t.Fatalf("unexpected trend timerange: %v", tr)
+	}
+	events := searchTypes[2].(map[string]interface{})
+	if events["type"] != "events" {
+		t.Fatalf("search type = %v, want events", events["type"])
+	}
+	if sort := events["sort"].(map[string]interface{}); sort["direction"] != "ASC" {
+		t.Fatalf("sort direction = %v, want ASC", sort["direction"])
+	}
+}
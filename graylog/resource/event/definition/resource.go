@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -15,7 +16,7 @@ func Resource() *schema.Resource {
 		Delete: destroy,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.EventDefinition),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -13,7 +14,7 @@ func Resource() *schema.Resource {
 		Delete: destroy,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.EventNotification),
 		},
 
 		Schema: map[string]*schema.Schema{
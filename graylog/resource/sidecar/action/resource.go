@@ -0,0 +1,103 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+)
+
+const (
+	keyAction       = "action"
+	keyNodeIDs      = "node_ids"
+	keyCollectorIDs = "collector_ids"
+	keyTriggers     = "triggers"
+)
+
+var actions = []string{"start", "stop", "restart"}
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		DeleteContext: resourceDelete,
+
+		Schema: map[string]*schema.Schema{
+			keyAction: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(actions, false),
+			},
+			keyNodeIDs: {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyCollectorIDs: {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyTriggers: {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	action := d.Get(keyAction).(string)
+	nodeIDs := sortedStrings(d.Get(keyNodeIDs).(*schema.Set))
+	collectorIDs := sortedStrings(d.Get(keyCollectorIDs).(*schema.Set))
+	collectors := make([]interface{}, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		collectors[i] = map[string]interface{}{
+			"sidecar_id":    nodeID,
+			"collector_ids": collectorIDs,
+		}
+	}
+	if _, err := cl.Sidecar.BulkAction(ctx, action, collectors); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to %s the collectors %v of the sidecars %v: %w", action, collectorIDs, nodeIDs, err))
+	}
+
+	// The action has no ID in Graylog.
+	d.SetId(action + "/" + strings.Join(nodeIDs, ",") + "/" + strings.Join(collectorIDs, ","))
+	return nil
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The action itself has no state in Graylog. The sidecars run it asynchronously
+	// and report the result as the collector status, see the graylog_sidecar_status data source.
+	return nil
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Nothing to do in Graylog. The collectors are kept in the state the action left them.
+	d.SetId("")
+	return nil
+}
+
+func sortedStrings(set *schema.Set) []string {
+	ret := convert.InterfaceListToStringList(set.List())
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,114 @@
+package action
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestAccSidecarCollectorAction(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceName := "graylog_sidecar_collector_action.test"
+	actions := 0
+
+	actionRoute := flute.Route{
+		Name: "run an action on collectors",
+		Matcher: flute.Matcher{
+			Method: "PUT",
+		},
+		Tester: flute.Tester{
+			Path:         "/api/sidecar/administration/action",
+			PartOfHeader: testutil.Header(),
+			// the sidecars and the collectors are sorted
+			BodyJSONString: `{
+  "action": "restart",
+  "collectors": [
+    {
+      "sidecar_id": "10517347-8fad-4f25-835f-95d1943fa338",
+      "collector_ids": ["5ec661032ab79c0012267d29"]
+    },
+    {
+      "sidecar_id": "20517347-8fad-4f25-835f-95d1943fa338",
+      "collector_ids": ["5ec661032ab79c0012267d29"]
+    }
+  ]
+}`,
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				actions++
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 202,
+			},
+		},
+	}
+
+	checkActions := func(exp int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if actions != exp {
+				return fmt.Errorf("the action should be run %d times, but run %d times", exp, actions)
+			}
+			return nil
+		}
+	}
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, actionRoute)
+		},
+		Config: `
+resource "graylog_sidecar_collector_action" "test" {
+  action        = "restart"
+  node_ids      = ["20517347-8fad-4f25-835f-95d1943fa338", "10517347-8fad-4f25-835f-95d1943fa338"]
+  collector_ids = ["5ec661032ab79c0012267d29"]
+  triggers = {
+    configuration = "abc"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", "restart/10517347-8fad-4f25-835f-95d1943fa338,20517347-8fad-4f25-835f-95d1943fa338/5ec661032ab79c0012267d29"),
+			checkActions(1),
+		),
+	}
+
+	triggerStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, actionRoute)
+		},
+		// the action is run again when the triggers are changed
+		Config: `
+resource "graylog_sidecar_collector_action" "test" {
+  action        = "restart"
+  node_ids      = ["20517347-8fad-4f25-835f-95d1943fa338", "10517347-8fad-4f25-835f-95d1943fa338"]
+  collector_ids = ["5ec661032ab79c0012267d29"]
+  triggers = {
+    configuration = "def"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "triggers.configuration", "def"),
+			checkActions(2),
+		),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_sidecar_collector_action", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			triggerStep,
+		},
+	})
+}
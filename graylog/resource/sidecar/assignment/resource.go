@@ -0,0 +1,260 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyNodeID          = "node_id"
+	keyNodeName        = "node_name"
+	keyCollectorID     = "collector_id"
+	keyConfigurationID = "configuration_id"
+	keyAssignments     = "assignments"
+	keyAssignedFromTag = "assigned_from_tags"
+	keySidecars        = "sidecars"
+)
+
+// nodeLocks serializes the changes of the assignments of each sidecar.
+// Graylog replaces all manual assignments of a sidecar at once,
+// so the resources of the same sidecar must not read and write them concurrently.
+var nodeLocks sync.Map
+
+func lockNode(nodeID string) func() {
+	a, _ := nodeLocks.LoadOrStore(nodeID, &sync.Mutex{})
+	mu := a.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		DeleteContext: resourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importer.GenStateFunc(
+				importer.ID(keyNodeID), importer.Parent(keyConfigurationID, importer.SidecarConfiguration)),
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyNodeID: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{keyNodeID, keyNodeName},
+			},
+			keyNodeName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{keyNodeID, keyNodeName},
+			},
+			keyCollectorID: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyConfigurationID: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeID := d.Get(keyNodeID).(string)
+	if nodeID == "" {
+		nodeID, err = getNodeIDByName(ctx, cl, d.Get(keyNodeName).(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	collectorID := d.Get(keyCollectorID).(string)
+	configurationID := d.Get(keyConfigurationID).(string)
+
+	unlock := lockNode(nodeID)
+	defer unlock()
+	if err := changeAssignments(ctx, cl, nodeID, func(assignments []interface{}) []interface{} {
+		for _, a := range assignments {
+			if a.(map[string]interface{})[keyConfigurationID] == configurationID {
+				return assignments
+			}
+		}
+		return append(assignments, map[string]interface{}{
+			keyCollectorID:     collectorID,
+			keyConfigurationID: configurationID,
+		})
+	}); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to assign the configuration %s to the sidecar %s: %w", configurationID, nodeID, err))
+	}
+
+	d.SetId(nodeID + "/" + configurationID)
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeID, configurationID, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sidecar, resp, err := cl.Sidecar.Get(ctx, nodeID)
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get the sidecar %s: %w", nodeID, err)))
+	}
+
+	var assignment map[string]interface{}
+	for _, a := range manualAssignments(sidecar) {
+		if a[keyConfigurationID] == configurationID {
+			assignment = a
+			break
+		}
+	}
+	if assignment == nil {
+		// The assignment was removed outside of Terraform.
+		d.SetId("")
+		return nil
+	}
+
+	for k, v := range map[string]interface{}{
+		keyNodeID:          nodeID,
+		keyNodeName:        sidecar[keyNodeName],
+		keyCollectorID:     assignment[keyCollectorID],
+		keyConfigurationID: configurationID,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeID, configurationID, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	unlock := lockNode(nodeID)
+	defer unlock()
+	if err := changeAssignments(ctx, cl, nodeID, func(assignments []interface{}) []interface{} {
+		ret := make([]interface{}, 0, len(assignments))
+		for _, a := range assignments {
+			if a.(map[string]interface{})[keyConfigurationID] != configurationID {
+				ret = append(ret, a)
+			}
+		}
+		return ret
+	}); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to unassign the configuration %s from the sidecar %s: %w", configurationID, nodeID, err))
+	}
+	return nil
+}
+
+// changeAssignments gets the manual assignments of the sidecar, changes them with fn and updates them.
+// The assignments of other sidecars and the assignments from tags aren't changed.
+func changeAssignments(
+	ctx context.Context, cl clientPkg.Client, nodeID string, fn func([]interface{}) []interface{},
+) error {
+	sidecar, _, err := cl.Sidecar.Get(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get the sidecar: %w", err)
+	}
+	manual := manualAssignments(sidecar)
+	assignments := make([]interface{}, len(manual))
+	for i, a := range manual {
+		assignments[i] = map[string]interface{}{
+			keyCollectorID:     a[keyCollectorID],
+			keyConfigurationID: a[keyConfigurationID],
+		}
+	}
+	_, err = cl.SidecarConfiguration.Assign(ctx, map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{
+				keyNodeID:      nodeID,
+				keyAssignments: fn(assignments),
+			},
+		},
+	})
+	return err
+}
+
+// manualAssignments returns the assignments of the sidecar except for the ones assigned from tags.
+func manualAssignments(sidecar map[string]interface{}) []map[string]interface{} {
+	list, _ := sidecar[keyAssignments].([]interface{})
+	ret := make([]map[string]interface{}, 0, len(list))
+	for _, a := range list {
+		assignment, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if tags, _ := assignment[keyAssignedFromTag].([]interface{}); len(tags) != 0 {
+			continue
+		}
+		ret = append(ret, assignment)
+	}
+	return ret
+}
+
+func getNodeIDByName(ctx context.Context, cl clientPkg.Client, nodeName string) (string, error) {
+	body, _, err := cl.Sidecar.GetAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list sidecars: %w", err)
+	}
+	sidecars, err := util.GetList(body, keySidecars)
+	if err != nil {
+		return "", err
+	}
+	nodeID := ""
+	for _, sidecar := range sidecars {
+		if name, _ := sidecar[keyNodeName].(string); name != nodeName {
+			continue
+		}
+		if nodeID != "" {
+			return "", fmt.Errorf("node_name isn't unique: %s", nodeName)
+		}
+		nodeID, _ = sidecar[keyNodeID].(string)
+	}
+	if nodeID == "" {
+		return "", fmt.Errorf("sidecar is not found: %s", nodeName)
+	}
+	return nodeID, nil
+}
+
+func parseID(id string) (string, string, error) {
+	i := strings.LastIndex(id, "/")
+	if i > 0 {
+		return id[:i], id[i+1:], nil
+	}
+	return "", "", errors.New("the ID of graylog_sidecar_assignment must be <node_id>/<configuration_id>: " + id)
+}
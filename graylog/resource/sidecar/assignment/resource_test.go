@@ -0,0 +1,171 @@
+package assignment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+const (
+	nodeID          = "10517347-8fad-4f25-835f-95d1943fa338"
+	collectorID     = "5ec661032ab79c0012267d29"
+	configurationID = "5ec709642ab79c001226edf9"
+)
+
+func TestAccSidecarAssignment(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	other := map[string]interface{}{"collector_id": "5ec661032ab79c0012267d2a", "configuration_id": "5ec709642ab79c001226edfa"}
+	tagAssignment := map[string]interface{}{
+		"collector_id": "5ec661032ab79c0012267d2b", "configuration_id": "5ec709642ab79c001226edfb",
+		"assigned_from_tags": []interface{}{"web"},
+	}
+	// the assignments of the sidecar in Graylog
+	assignments := []interface{}{other, tagAssignment}
+
+	sidecarBody := func() (string, error) {
+		b, err := json.Marshal(map[string]interface{}{
+			"node_id": nodeID, "node_name": "web-1", "assignments": assignments,
+		})
+		return string(b), err
+	}
+
+	resourceName := "graylog_sidecar_assignment.test"
+
+	getListRoute := flute.Route{
+		Name: "list sidecars",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecars/all",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				body, err := sidecarBody()
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"sidecars": [` + body + `]}`)),
+				}, nil
+			},
+		},
+	}
+
+	getRoute := flute.Route{
+		Name: "get a sidecar",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecars/" + nodeID,
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				body, err := sidecarBody()
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+				}, nil
+			},
+		},
+	}
+
+	assignRoute := flute.Route{
+		Name: "assign configurations to sidecars",
+		Matcher: flute.Matcher{
+			Method: "PUT",
+			Path:   "/api/sidecars/configurations",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				body := struct {
+					Nodes []struct {
+						NodeID      string        `json:"node_id"`
+						Assignments []interface{} `json:"assignments"`
+					} `json:"nodes"`
+				}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				require.Len(t, body.Nodes, 1)
+				require.Equal(t, nodeID, body.Nodes[0].NodeID)
+				// The assignment from tags isn't sent as a manual assignment.
+				assignments = append(body.Nodes[0].Assignments, tagAssignment)
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 202,
+			},
+		},
+	}
+
+	checkAssignments := func(exp ...interface{}) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			all := append(exp, tagAssignment)
+			if !reflect.DeepEqual(all, assignments) {
+				return fmt.Errorf("the assignments of the sidecar should be %v, but %v", all, assignments)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_sidecar_assignment", Resource()),
+		// Only the assignment of the resource is removed.
+		CheckDestroy: checkAssignments(other),
+		Steps: []resource.TestStep{
+			{
+				ResourceName: resourceName,
+				PreConfig: func() {
+					testutil.SetHTTPClient(t, getListRoute, getRoute, assignRoute)
+				},
+				Config: `
+resource "graylog_sidecar_assignment" "test" {
+  node_name        = "web-1"
+  collector_id     = "5ec661032ab79c0012267d29"
+  configuration_id = "5ec709642ab79c001226edf9"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", nodeID+"/"+configurationID),
+					resource.TestCheckResourceAttr(resourceName, "node_id", nodeID),
+					checkAssignments(other, map[string]interface{}{
+						"collector_id": collectorID, "configuration_id": configurationID,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestParseID(t *testing.T) {
+	node, configuration, err := parseID(nodeID + "/" + configurationID)
+	require.Nil(t, err)
+	require.Equal(t, nodeID, node)
+	require.Equal(t, configurationID, configuration)
+
+	_, _, err = parseID(nodeID)
+	require.NotNil(t, err)
+}
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 )
 
 func Resource() *schema.Resource {
@@ -12,7 +13,7 @@ func Resource() *schema.Resource {
 		Delete: destroy,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.SidecarCollector),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 )
 
 func Resource() *schema.Resource {
@@ -11,8 +12,10 @@ func Resource() *schema.Resource {
 		Update: update,
 		Delete: destroy,
 
+		CustomizeDiff: customizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.SidecarConfiguration),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,6 +35,18 @@ func Resource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// The names of the configuration variables which are referenced in the template and managed by Terraform.
+			// It isn't sent to Graylog.
+			keyVariables: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -51,7 +51,8 @@ func TestAccConfiguration(t *testing.T) {
   "collector_id": "5ec65adb2ab79c001226759c",
   "name": "foo",
   "color": "#00796b",
-  "template": "fields_under_root: true"
+  "template": "fields_under_root: true",
+  "tags": []
 }`,
 			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
 				configurationBody = `{
@@ -107,7 +108,8 @@ resource "graylog_sidecar_configuration" "test" {
   "collector_id": "5ec65adb2ab79c001226759c",
   "name": "foo_updated",
   "color": "#00796b",
-  "template": "fields_under_root: true"
+  "template": "fields_under_root: true",
+  "tags": []
 }`,
 			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
 				configurationBody = `{
@@ -13,7 +13,12 @@ const (
 )
 
 func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
-	return convert.GetFromResourceData(d, Resource())
+	data, err := convert.GetFromResourceData(d, Resource())
+	if err != nil {
+		return nil, err
+	}
+	delete(data, keyVariables)
+	return data, nil
 }
 
 func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
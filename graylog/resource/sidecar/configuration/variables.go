@@ -0,0 +1,70 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+)
+
+const (
+	keyTemplate  = "template"
+	keyVariables = "variables"
+)
+
+// userVariablePattern matches the references to configuration variables in a template, e.g. ${user.graylog_host}.
+var userVariablePattern = regexp.MustCompile(`\$\{\s*user\.([A-Za-z0-9_]+)`)
+
+func customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(keyTemplate) || !d.NewValueKnown(keyVariables) {
+		return nil
+	}
+	return checkVariables(ctx, m, d.Get(keyTemplate).(string), d.Get(keyVariables).(*schema.Set).List())
+}
+
+// checkVariables returns an error if the template references configuration variables
+// which are neither declared in variables nor exist in Graylog.
+// Graylog is requested only if some referenced variables aren't declared.
+func checkVariables(ctx context.Context, m interface{}, template string, declared []interface{}) error {
+	undefined := map[string]struct{}{}
+	for _, match := range userVariablePattern.FindAllStringSubmatch(template, -1) {
+		undefined[match[1]] = struct{}{}
+	}
+	for _, name := range declared {
+		delete(undefined, name.(string))
+	}
+	if len(undefined) == 0 {
+		return nil
+	}
+
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	variables, _, err := cl.SidecarVariable.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sidecar configuration variables to check the template: %w", err)
+	}
+	for _, variable := range variables {
+		if name, ok := variable["name"].(string); ok {
+			delete(undefined, name)
+		}
+	}
+	if len(undefined) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(undefined))
+	for name := range undefined {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf(
+		"the template references undefined configuration variables: %s. "+
+			"Create them with graylog_sidecar_configuration_variable and add their names to %s",
+		strings.Join(names, ", "), keyVariables)
+}
@@ -0,0 +1,61 @@
+package configuration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/config"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestCheckVariables(t *testing.T) {
+	requested := 0
+	testutil.SetHTTPClient(t, flute.Route{
+		Name: "list configuration variables",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   "/api/sidecar/configuration_variables",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				requested++
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `[
+  {
+    "id": "5ec709642ab79c001226edf9",
+    "name": "graylog_host",
+    "content": "graylog.example.com"
+  }
+]`,
+		},
+	})
+	cfg := config.Config{Endpoint: "http://example.com/api", AuthName: "admin", AuthPassword: "admin"}
+	ctx := context.Background()
+
+	template := "output.logstash:\n  hosts: [\"${user.graylog_host}:${user.beats_port}\"]\n"
+
+	// all references are declared
+	require.Nil(t, checkVariables(ctx, cfg, template, []interface{}{"graylog_host", "beats_port"}))
+	require.Equal(t, 0, requested)
+
+	// graylog_host exists in Graylog
+	require.Nil(t, checkVariables(ctx, cfg, template, []interface{}{"beats_port"}))
+	require.Equal(t, 1, requested)
+
+	err := checkVariables(ctx, cfg, template+"tags: [${ user.env }]\n", nil)
+	require.EqualError(t, err, "the template references undefined configuration variables: beats_port, env. "+
+		"Create them with graylog_sidecar_configuration_variable and add their names to variables")
+
+	// no reference
+	require.Nil(t, checkVariables(ctx, cfg, "fields_under_root: true", nil))
+	require.Equal(t, 2, requested)
+}
@@ -12,7 +12,7 @@ func Resource() *schema.Resource {
 		Delete: destroy,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importState,
 		},
 
 		Schema: map[string]*schema.Schema{
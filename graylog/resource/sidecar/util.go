@@ -1,6 +1,8 @@
 package sidecar
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
@@ -31,3 +33,10 @@ func setDataToResourceData(d *schema.ResourceData, data map[string]interface{})
 	d.SetId(systemID)
 	return nil
 }
+
+// importState imports graylog_sidecars. The resource manages the assignments of all sidecars,
+// so the import ID is ignored and replaced with the fixed ID.
+func importState(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(systemID)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,129 @@
+package variable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyID          = "id"
+	keyName        = "name"
+	keyDescription = "description"
+	keyContent     = "content"
+)
+
+// NamePattern is the pattern of the names of configuration variables which Graylog accepts.
+var NamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importer.StateFunc(importer.SidecarConfigurationVariable),
+		},
+
+		Schema: map[string]*schema.Schema{
+			keyName: {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringMatch(
+					NamePattern, "the name must consist of the characters A-Z, a-z, 0-9 and _"),
+			},
+			keyDescription: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			keyContent: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func getData(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		keyName:        d.Get(keyName),
+		keyDescription: d.Get(keyDescription),
+		keyContent:     d.Get(keyContent),
+	}
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, _, err := cl.SidecarVariable.Create(ctx, getData(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create a sidecar configuration variable %s: %w", d.Get(keyName), err))
+	}
+	id, ok := body[keyID].(string)
+	if !ok || id == "" {
+		return diag.FromErr(errors.New("the response of Graylog API is unexpected. id of the sidecar configuration variable is empty"))
+	}
+	d.SetId(id)
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	variables, resp, err := cl.SidecarVariable.Gets(ctx)
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get sidecar configuration variables: %w", err)))
+	}
+	for _, variable := range variables {
+		if variable[keyID] != d.Id() {
+			continue
+		}
+		for _, k := range []string{keyName, keyDescription, keyContent} {
+			if err := d.Set(k, variable[k]); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return nil
+	}
+	// The variable was removed outside of Terraform.
+	d.SetId("")
+	return nil
+}
+
+func resourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Graylog replaces the variable name in the configuration templates when the variable is renamed.
+	if _, _, err := cl.SidecarVariable.Update(ctx, d.Id(), getData(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to update a sidecar configuration variable %s: %w", d.Id(), err))
+	}
+	return resourceRead(ctx, d, m)
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := cl.SidecarVariable.Delete(ctx, d.Id()); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete a sidecar configuration variable %s: %w", d.Id(), err))
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package variable
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestAccSidecarConfigurationVariable(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	variablesBody := "[]"
+	resourcePath := "/api/sidecar/configuration_variables/5ec709642ab79c001226edf9"
+	resourceName := "graylog_sidecar_configuration_variable.test"
+
+	// the variable is read from the list of the variables
+	getRoute := flute.Route{
+		Name: "list configuration variables",
+		Matcher: flute.Matcher{
+			Method: "GET",
+		},
+		Tester: flute.Tester{
+			Path:         "/api/sidecar/configuration_variables",
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(variablesBody)),
+				}, nil
+			},
+		},
+	}
+
+	postRoute := flute.Route{
+		Name: "create a configuration variable",
+		Matcher: flute.Matcher{
+			Method: "POST",
+		},
+		Tester: flute.Tester{
+			Path:         "/api/sidecar/configuration_variables",
+			PartOfHeader: testutil.Header(),
+			BodyJSONString: `{
+  "name": "graylog_host",
+  "description": "",
+  "content": "graylog.example.com"
+}`,
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				variablesBody = `[
+  {
+    "id": "5ec709642ab79c001226edf9",
+    "name": "graylog_host",
+    "description": "",
+    "content": "graylog.example.com"
+  }
+]`
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "id": "5ec709642ab79c001226edf9",
+  "name": "graylog_host",
+  "description": "",
+  "content": "graylog.example.com"
+}`,
+		},
+	}
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, postRoute)
+		},
+		Config: `
+resource "graylog_sidecar_configuration_variable" "test" {
+  name    = "graylog_host"
+  content = "graylog.example.com"
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", "5ec709642ab79c001226edf9"),
+			resource.TestCheckResourceAttr(resourceName, "content", "graylog.example.com"),
+		),
+	}
+
+	updateRoute := flute.Route{
+		Name: "update a configuration variable",
+		Matcher: flute.Matcher{
+			Method: "PUT",
+		},
+		Tester: flute.Tester{
+			Path:         resourcePath,
+			PartOfHeader: testutil.Header(),
+			BodyJSONString: `{
+  "name": "graylog_host",
+  "description": "the host of Graylog",
+  "content": "graylog2.example.com"
+}`,
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				variablesBody = `[
+  {
+    "id": "5ec709642ab79c001226edf9",
+    "name": "graylog_host",
+    "description": "the host of Graylog",
+    "content": "graylog2.example.com"
+  }
+]`
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "id": "5ec709642ab79c001226edf9",
+  "name": "graylog_host",
+  "description": "the host of Graylog",
+  "content": "graylog2.example.com"
+}`,
+		},
+	}
+
+	deleteRoute := flute.Route{
+		Name: "delete a configuration variable",
+		Matcher: flute.Matcher{
+			Method: "DELETE",
+		},
+		Tester: flute.Tester{
+			Path:         resourcePath,
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 204,
+			},
+		},
+	}
+
+	updateStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, updateRoute, deleteRoute)
+		},
+		Config: `
+resource "graylog_sidecar_configuration_variable" "test" {
+  name        = "graylog_host"
+  description = "the host of Graylog"
+  content     = "graylog2.example.com"
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "description", "the host of Graylog"),
+			resource.TestCheckResourceAttr(resourceName, "content", "graylog2.example.com"),
+		),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_sidecar_configuration_variable", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			updateStep,
+		},
+	})
+}
+
+func TestValidateName(t *testing.T) {
+	_, errs := Resource().Schema["name"].ValidateFunc("graylog_host", "name")
+	require.Empty(t, errs)
+	_, errs = Resource().Schema["name"].ValidateFunc("graylog-host", "name")
+	require.Len(t, errs, 1)
+}
@@ -2,6 +2,7 @@ package condition
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -16,7 +17,7 @@ func Resource() *schema.Resource {
 		StateUpgraders: stateUpgraders,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: util.GenStateFunc("stream_id", "alert_condition_id"),
+			StateContext: importer.GenStateFunc(importer.Parent("stream_id", importer.Stream), importer.ID("alert_condition_id")),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,333 @@
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keySourceStreamID  = "source_stream_id"
+	keyTitle           = "title"
+	keyIndexSetID      = "index_set_id"
+	keyDescription     = "description"
+	keyRemoveMatches   = "remove_matches_from_default_stream"
+	keyDisabled        = "disabled"
+	keyRuleOverride    = "rule_override"
+	keySourceRuleID    = "source_rule_id"
+	keyValue           = "value"
+	keyMatchingType    = "matching_type"
+	keyRules           = "rules"
+	keyOutputs         = "outputs"
+	keyOutputIDs       = "output_ids"
+	keyOperator        = "operator"
+	keyField           = "field"
+	keyInverted        = "inverted"
+	keyID              = "id"
+	keyStreamID        = "stream_id"
+	keyCreatorUserID   = "creator_user_id"
+	keyCreatedAt       = "created_at"
+	importIDSeparator  = "/"
+	importIDDescriptor = keySourceStreamID + importIDSeparator + keyStreamID
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+
+		CustomizeDiff: customizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			keySourceStreamID: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			keyTitle: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			keyIndexSetID: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Optional
+			keyDescription: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			keyRemoveMatches: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			keyDisabled: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyRuleOverride: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keySourceRuleID: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						keyValue: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			// Computed
+			keyMatchingType: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			keyRules: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						keyOperator: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyField: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyValue: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyDescription: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						keyInverted: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			keyOutputIDs: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyCreatorUserID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			keyCreatedAt: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sourceID := d.Get(keySourceStreamID).(string)
+	source, _, err := cl.Stream.Get(ctx, sourceID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get the source stream %s: %w", sourceID, err))
+	}
+	src, err := newTemplate(source, d.Get(keyRuleOverride).(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := map[string]interface{}{
+		keyTitle:         d.Get(keyTitle),
+		keyIndexSetID:    d.Get(keyIndexSetID),
+		keyDescription:   source[keyDescription],
+		keyRemoveMatches: source[keyRemoveMatches],
+	}
+	if v, ok := d.GetOk(keyDescription); ok {
+		data[keyDescription] = v
+	}
+	if v, ok := d.GetOkExists(keyRemoveMatches); ok {
+		data[keyRemoveMatches] = v
+	}
+	body, _, err := cl.Stream.Clone(ctx, sourceID, data)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to clone the stream %s: %w", sourceID, err))
+	}
+	id, ok := body[keyStreamID].(string)
+	if !ok || id == "" {
+		return diag.FromErr(errors.New("the response of Graylog API is unexpected. stream_id is empty"))
+	}
+	d.SetId(id)
+
+	// The clone has the rules of the source stream. Only the overridden rules are replaced.
+	if d.Get(keyRuleOverride).(*schema.Set).Len() != 0 {
+		if err := reconcileRules(ctx, cl, id, src.rules); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Graylog pauses the clone
+	if !d.Get(keyDisabled).(bool) {
+		if _, err := cl.Stream.Resume(ctx, id); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to resume a stream %s: %w", id, err))
+		}
+	}
+
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	stream, resp, err := cl.Stream.Get(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get a stream %s: %w", d.Id(), err)))
+	}
+
+	rules, _ := stream[keyRules].([]interface{})
+	for k, v := range map[string]interface{}{
+		keyTitle:         stream[keyTitle],
+		keyIndexSetID:    stream[keyIndexSetID],
+		keyDescription:   stream[keyDescription],
+		keyRemoveMatches: stream[keyRemoveMatches],
+		keyDisabled:      stream[keyDisabled],
+		keyMatchingType:  stream[keyMatchingType],
+		keyRules:         rule.Flatten(rules),
+		keyOutputIDs:     outputIDs(stream),
+		keyCreatorUserID: stream[keyCreatorUserID],
+		keyCreatedAt:     stream[keyCreatedAt],
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := d.Id()
+
+	if d.HasChanges(keyTitle, keyIndexSetID, keyDescription, keyRemoveMatches, keyMatchingType) {
+		// Graylog 7 Update requires id in body
+		data := map[string]interface{}{
+			keyID:            id,
+			keyTitle:         d.Get(keyTitle),
+			keyIndexSetID:    d.Get(keyIndexSetID),
+			keyDescription:   d.Get(keyDescription),
+			keyRemoveMatches: d.Get(keyRemoveMatches),
+			keyMatchingType:  d.Get(keyMatchingType),
+		}
+		if _, _, err := cl.Stream.Update(ctx, id, data); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update a stream %s: %w", id, err))
+		}
+	}
+
+	if d.HasChange(keyRules) {
+		if err := reconcileRules(ctx, cl, id, d.Get(keyRules).(*schema.Set).List()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange(keyOutputIDs) {
+		o, n := d.GetChange(keyOutputIDs)
+		oldIDs, newIDs := o.(*schema.Set), n.(*schema.Set)
+		if added := newIDs.Difference(oldIDs); added.Len() != 0 {
+			if _, err := cl.StreamOutput.AssociateOutputsWithStream(
+				ctx, id, convert.InterfaceListToStringList(added.List())); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to associate outputs with the stream %s: %w", id, err))
+			}
+		}
+		for _, outputID := range oldIDs.Difference(newIDs).List() {
+			if _, err := cl.StreamOutput.Delete(ctx, id, outputID.(string)); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to remove the output %s from the stream %s: %w", outputID, id, err))
+			}
+		}
+	}
+
+	if d.HasChange(keyDisabled) {
+		if d.Get(keyDisabled).(bool) {
+			if _, err := cl.Stream.Pause(ctx, id); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to pause a stream %s: %w", id, err))
+			}
+		} else {
+			if _, err := cl.Stream.Resume(ctx, id); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to resume a stream %s: %w", id, err))
+			}
+		}
+	}
+
+	return resourceRead(ctx, d, m)
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := cl.Stream.Delete(ctx, d.Id()); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete a stream %s: %w", d.Id(), err))
+	}
+	return nil
+}
+
+// importState imports the clone with the ID "<source stream>/<stream>".
+// Both streams can be given by the title.
+func importState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	a := importer.SplitID(d.Id(), 2)
+	if len(a) != 2 {
+		return nil, errors.New("format of import argument should be " + importIDDescriptor)
+	}
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return nil, err
+	}
+	sourceID, err := importer.Stream(ctx, cl, a[0])
+	if err != nil {
+		return nil, err
+	}
+	id, err := importer.Stream(ctx, cl, a[1])
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set(keySourceStreamID, sourceID); err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,260 @@
+package clone
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+const (
+	sourceID = "5ea26bb42ab79c0012521287"
+	cloneID  = "5ea26bb42ab79c0012521300"
+)
+
+func TestAccStreamClone(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the streams in Graylog
+	streams := map[string]map[string]interface{}{
+		sourceID: {
+			"id": sourceID, "title": "template", "description": "team template", "matching_type": "AND",
+			"index_set_id": "5e9861442ab79c0012e7d1c4", "remove_matches_from_default_stream": true, "disabled": false,
+			"rules": []interface{}{
+				map[string]interface{}{"id": "5ea26bb42ab79c0012521288", "type": 1, "field": "source", "value": "team-a", "inverted": false, "description": ""},
+				map[string]interface{}{"id": "5ea26bb42ab79c0012521289", "type": 5, "field": "request", "value": "", "inverted": false, "description": ""},
+			},
+			"outputs": []interface{}{map[string]interface{}{"id": "5ea26bb42ab79c0012521400", "title": "gelf"}},
+		},
+	}
+
+	getRoute := func(id string) flute.Route {
+		return flute.Route{
+			Name: "get a stream",
+			Matcher: flute.Matcher{
+				Method: "GET",
+				Path:   "/api/streams/" + id,
+			},
+			Tester: flute.Tester{
+				PartOfHeader: testutil.Header(),
+			},
+			Response: flute.Response{
+				Response: func(req *http.Request) (*http.Response, error) {
+					b, err := json.Marshal(streams[id])
+					if err != nil {
+						return nil, err
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(string(b))),
+					}, nil
+				},
+			},
+		}
+	}
+
+	cloneRoute := flute.Route{
+		Name: "clone a stream",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/streams/" + sourceID + "/clone",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			// the description and remove_matches_from_default_stream of the source stream are kept
+			BodyJSONString: `{
+  "title": "team-b",
+  "index_set_id": "5e9861442ab79c0012e7d1c5",
+  "description": "team template",
+  "remove_matches_from_default_stream": true
+}`,
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				// Graylog copies the rules and outputs and pauses the clone
+				streams[cloneID] = map[string]interface{}{
+					"id": cloneID, "title": "team-b", "description": "team template", "matching_type": "AND",
+					"index_set_id": "5e9861442ab79c0012e7d1c5", "remove_matches_from_default_stream": true, "disabled": true,
+					"rules": []interface{}{
+						map[string]interface{}{"id": "5ea26bb42ab79c0012521301", "type": 1, "field": "source", "value": "team-a", "inverted": false, "description": ""},
+						map[string]interface{}{"id": "5ea26bb42ab79c0012521302", "type": 5, "field": "request", "value": "", "inverted": false, "description": ""},
+					},
+					"outputs": []interface{}{map[string]interface{}{"id": "5ea26bb42ab79c0012521400", "title": "gelf"}},
+				}
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "stream_id": "5ea26bb42ab79c0012521300"
+}`,
+		},
+	}
+
+	createRuleRoute := flute.Route{
+		Name: "create a stream rule",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/streams/" + cloneID + "/rules",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				r := map[string]interface{}{}
+				if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+					t.Fatal(err)
+				}
+				// the overridden rule is created
+				require.Equal(t, "team-b", r["value"])
+				r["id"] = "5ea26bb42ab79c0012521303"
+				streams[cloneID]["rules"] = append(streams[cloneID]["rules"].([]interface{}), r)
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 201,
+			},
+			BodyString: `{
+  "streamrule_id": "5ea26bb42ab79c0012521303"
+}`,
+		},
+	}
+
+	deleteRuleRoute := flute.Route{
+		Name: "delete a stream rule",
+		Matcher: flute.Matcher{
+			Method: "DELETE",
+			Path:   "/api/streams/" + cloneID + "/rules/5ea26bb42ab79c0012521301",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				streams[cloneID]["rules"] = streams[cloneID]["rules"].([]interface{})[1:]
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 204,
+			},
+		},
+	}
+
+	resumeRoute := flute.Route{
+		Name: "resume a stream",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/streams/" + cloneID + "/resume",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				streams[cloneID]["disabled"] = false
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 204,
+			},
+		},
+	}
+
+	deleteRoute := flute.Route{
+		Name: "delete a stream",
+		Matcher: flute.Matcher{
+			Method: "DELETE",
+			Path:   "/api/streams/" + cloneID,
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 204,
+			},
+		},
+	}
+
+	resourceName := "graylog_stream_clone.test"
+	config := `
+resource "graylog_stream_clone" "test" {
+  source_stream_id = "5ea26bb42ab79c0012521287"
+  title            = "team-b"
+  index_set_id     = "5e9861442ab79c0012e7d1c5"
+
+  rule_override {
+    source_rule_id = "5ea26bb42ab79c0012521288"
+    value          = "team-b"
+  }
+}
+`
+
+	unknownRuleStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute(sourceID))
+		},
+		Config: `
+resource "graylog_stream_clone" "test" {
+  source_stream_id = "5ea26bb42ab79c0012521287"
+  title            = "team-b"
+  index_set_id     = "5e9861442ab79c0012e7d1c5"
+
+  rule_override {
+    source_rule_id = "5ea26bb42ab79c0012521299"
+    value          = "team-b"
+  }
+}
+`,
+		ExpectError: regexp.MustCompile("the rules 5ea26bb42ab79c0012521299 of rule_override aren't rules of the source stream"),
+	}
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute(sourceID), getRoute(cloneID),
+				cloneRoute, createRuleRoute, deleteRuleRoute, resumeRoute, deleteRoute)
+		},
+		Config: config,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", cloneID),
+			resource.TestCheckResourceAttr(resourceName, "disabled", "false"),
+			resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+			resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rules.*", map[string]string{
+				"operator": "equals",
+				"field":    "source",
+				"value":    "team-b",
+			}),
+			resource.TestCheckResourceAttr(resourceName, "output_ids.#", "1"),
+		),
+	}
+
+	sourceChangedStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			// a rule of the source stream is changed
+			streams[sourceID]["rules"].([]interface{})[1].(map[string]interface{})["field"] = "path"
+			testutil.SetHTTPClient(t, getRoute(sourceID), getRoute(cloneID), deleteRoute)
+		},
+		Config:             config,
+		PlanOnly:           true,
+		ExpectNonEmptyPlan: true,
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_stream_clone", Resource()),
+		Steps: []resource.TestStep{
+			unknownRuleStep,
+			createStep,
+			sourceChangedStep,
+		},
+	})
+}
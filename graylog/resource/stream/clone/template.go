@@ -0,0 +1,125 @@
+package clone
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
+)
+
+// template is the layout which the clone should have: the rules and outputs of the source stream,
+// and the rule values overridden by rule_override.
+type template struct {
+	rules        []interface{}
+	outputIDs    []interface{}
+	matchingType interface{}
+}
+
+func newTemplate(source map[string]interface{}, overrides []interface{}) (*template, error) {
+	values := make(map[string]string, len(overrides))
+	for _, a := range overrides {
+		o := a.(map[string]interface{})
+		values[o[keySourceRuleID].(string)] = o[keyValue].(string)
+	}
+
+	sourceRules, _ := source[keyRules].([]interface{})
+	rules := make([]interface{}, 0, len(sourceRules))
+	for _, a := range sourceRules {
+		r, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := r[keyID].(string)
+		if value, ok := values[id]; ok {
+			c := make(map[string]interface{}, len(r))
+			for k, v := range r {
+				c[k] = v
+			}
+			c[keyValue] = value
+			r = c
+			delete(values, id)
+		}
+		rules = append(rules, r)
+	}
+	if len(values) != 0 {
+		ids := make([]string, 0, len(values))
+		for id := range values {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return nil, fmt.Errorf("the rules %s of rule_override aren't rules of the source stream %s",
+			strings.Join(ids, ", "), source[keyID])
+	}
+
+	return &template{
+		rules:        rule.Flatten(rules),
+		outputIDs:    outputIDs(source),
+		matchingType: source[keyMatchingType],
+	}, nil
+}
+
+// outputIDs returns the IDs of the outputs of the stream.
+func outputIDs(stream map[string]interface{}) []interface{} {
+	outputs, _ := stream[keyOutputs].([]interface{})
+	ids := make([]interface{}, 0, len(outputs))
+	for _, a := range outputs {
+		switch output := a.(type) {
+		case string:
+			ids = append(ids, output)
+		case map[string]interface{}:
+			if id, ok := output[keyID].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// customizeDiff tracks the drift of the clone against the source stream.
+// The rules, outputs and matching type which the clone should have are computed from the source stream,
+// so changes of the source stream and of the clone outside Terraform are shown as a diff.
+func customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(keySourceStreamID) || !d.NewValueKnown(keyRuleOverride) {
+		return nil
+	}
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return err
+	}
+	sourceID := d.Get(keySourceStreamID).(string)
+	source, _, err := cl.Stream.Get(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to get the source stream %s: %w", sourceID, err)
+	}
+	src, err := newTemplate(source, d.Get(keyRuleOverride).(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	for k, v := range map[string]interface{}{
+		keyRules:        src.rules,
+		keyOutputIDs:    src.outputIDs,
+		keyMatchingType: src.matchingType,
+	} {
+		if err := d.SetNew(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileRules makes the rules of the stream the same as the rule blocks.
+func reconcileRules(ctx context.Context, cl clientPkg.Client, streamID string, blocks []interface{}) error {
+	stream, _, err := cl.Stream.Get(ctx, streamID)
+	if err != nil {
+		return fmt.Errorf("failed to get a stream %s to update its rules: %w", streamID, err)
+	}
+	current, _ := stream[keyRules].([]interface{})
+	return rule.Reconcile(ctx, cl, streamID, current, rule.Expand(blocks))
+}
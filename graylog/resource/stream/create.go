@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -23,6 +24,12 @@ func create(d *schema.ResourceData, m interface{}) error {
 	disabled := data[keyDisabled].(bool)
 	delete(data, keyDisabled)
 
+	// The rules are created with the stream, which is paused until they are created.
+	rules := rule.Expand(d.Get(keyRule))
+	if len(rules) != 0 {
+		data[keyRules] = rules
+	}
+
 	stream, _, err := cl.Stream.Create(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to create a stream: %w", err)
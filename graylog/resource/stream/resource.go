@@ -2,6 +2,7 @@ package stream
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 )
 
 func Resource() *schema.Resource {
@@ -11,8 +12,10 @@ func Resource() *schema.Resource {
 		Update: update,
 		Delete: destroy,
 
+		CustomizeDiff: customizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.Stream),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -28,7 +31,7 @@ func Resource() *schema.Resource {
 			},
 
 			// Optional
-			// rules
+			keyRule: schemaRule(),
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -44,6 +47,7 @@ func Resource() *schema.Resource {
 			},
 
 			// attributes
+			keyRuleIDs: schemaRuleIDs(),
 			"creator_user_id": {
 				Type:     schema.TypeString,
 				Computed: true,
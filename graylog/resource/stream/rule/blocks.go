@@ -0,0 +1,161 @@
+package rule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+)
+
+const (
+	keyDescription = "description"
+	keyInverted    = "inverted"
+)
+
+// Expand converts rule blocks to the stream rules of Graylog API.
+// A rule block has operator, field, value, description and inverted.
+func Expand(v interface{}) []interface{} {
+	var list []interface{}
+	switch a := v.(type) {
+	case *schema.Set:
+		list = a.List()
+	case []interface{}:
+		list = a
+	}
+	rules := make([]interface{}, len(list))
+	for i, a := range list {
+		r := a.(map[string]interface{})
+		rules[i] = map[string]interface{}{
+			keyType:        Operators[r[keyOperator].(string)],
+			keyField:       r[keyField],
+			keyValue:       r[keyValue],
+			keyDescription: r[keyDescription],
+			keyInverted:    r[keyInverted],
+		}
+	}
+	return rules
+}
+
+// Flatten converts the stream rules of Graylog API to rule blocks.
+func Flatten(rules []interface{}) []interface{} {
+	blocks := make([]interface{}, 0, len(rules))
+	for _, a := range rules {
+		r, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		t, _ := r[keyType].(float64)
+		operator, _ := OperatorName(int(t))
+		field, _ := r[keyField].(string)
+		value, _ := r[keyValue].(string)
+		description, _ := r[keyDescription].(string)
+		inverted, _ := r[keyInverted].(bool)
+		blocks = append(blocks, map[string]interface{}{
+			keyOperator:    operator,
+			keyField:       field,
+			keyValue:       value,
+			keyDescription: description,
+			keyInverted:    inverted,
+		})
+	}
+	return blocks
+}
+
+// ruleKey returns the key to compare a rule block with a stream rule.
+func ruleKey(r map[string]interface{}) string {
+	t := r[keyType]
+	if f, ok := t.(float64); ok {
+		t = int(f)
+	}
+	field, _ := r[keyField].(string)
+	value, _ := r[keyValue].(string)
+	description, _ := r[keyDescription].(string)
+	inverted, _ := r[keyInverted].(bool)
+	return fmt.Sprintf("%v\x00%s\x00%s\x00%t\x00%s", t, field, value, inverted, description)
+}
+
+// Split splits the rules of a stream into the rules which are managed by the rule blocks and the other rules.
+// The rules whose IDs are in ruleIDs are managed. A rule which isn't in ruleIDs is managed if it matches a rule block
+// which has no managed rule yet, e.g. after the stream is created or imported.
+func Split(rules []interface{}, ruleIDs *schema.Set, desired []interface{}) (managed, foreign []interface{}) {
+	missing := map[string]int{}
+	for _, a := range desired {
+		missing[ruleKey(a.(map[string]interface{}))]++
+	}
+	var others []map[string]interface{}
+	for _, a := range rules {
+		r, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := r[keyID].(string); !ok || !ruleIDs.Contains(id) {
+			others = append(others, r)
+			continue
+		}
+		managed = append(managed, r)
+		if k := ruleKey(r); missing[k] > 0 {
+			missing[k]--
+		}
+	}
+	for _, r := range others {
+		k := ruleKey(r)
+		if missing[k] == 0 {
+			foreign = append(foreign, r)
+			continue
+		}
+		missing[k]--
+		managed = append(managed, r)
+	}
+	return managed, foreign
+}
+
+// Reconcile makes the rules of the stream the same as the desired rules.
+// The rules which are in both are kept. The missing rules are created before the other rules are deleted,
+// so the stream doesn't route messages with an empty set of rules in between.
+func Reconcile(ctx context.Context, cl client.Client, streamID string, current, desired []interface{}) error {
+	existing := map[string][]string{}
+	var ids []string
+	for _, a := range current {
+		r, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := r[keyID].(string)
+		if !ok {
+			return errors.New("the response of Graylog API is unexpected. id of the stream rule is empty")
+		}
+		k := ruleKey(r)
+		existing[k] = append(existing[k], id)
+		ids = append(ids, id)
+	}
+
+	for _, a := range desired {
+		r := a.(map[string]interface{})
+		k := ruleKey(r)
+		if ids := existing[k]; len(ids) != 0 {
+			existing[k] = ids[1:]
+			continue
+		}
+		if _, _, err := cl.StreamRule.Create(ctx, streamID, r); err != nil {
+			return fmt.Errorf("failed to create a rule of the stream %s: %w", streamID, err)
+		}
+	}
+
+	removed := map[string]struct{}{}
+	for _, a := range existing {
+		for _, id := range a {
+			removed[id] = struct{}{}
+		}
+	}
+	for _, id := range ids {
+		if _, ok := removed[id]; !ok {
+			continue
+		}
+		if _, err := cl.StreamRule.Delete(ctx, streamID, id); err != nil {
+			return fmt.Errorf("failed to delete the rule %s of the stream %s: %w", id, streamID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sourceInputField is the field of a message which has the ID of the input which received the message.
+const sourceInputField = "gl2_source_input"
+
+// Match evaluates a stream rule against a message like the stream rule matchers of Graylog.
+// A missing field matches only inverted rules, and numbers which can't be parsed never match.
+// Regular expressions are evaluated with Go's RE2 syntax, so an error is returned
+// if the expression uses constructs which only Java supports.
+func Match(operator, field, value string, inverted bool, message map[string]interface{}) (bool, error) {
+	switch operator {
+	case "always_match":
+		return true, nil
+	case "match_input":
+		input, _ := message[sourceInputField].(string)
+		return inverted != (input == value), nil
+	}
+
+	a, ok := message[field]
+	if !ok || a == nil {
+		return inverted, nil
+	}
+	s := fmt.Sprint(a)
+
+	switch operator {
+	case "equals":
+		return inverted != (s == value), nil
+	case "contains":
+		return inverted != strings.Contains(s, value), nil
+	case "present":
+		return inverted != (s != ""), nil
+	case "regex":
+		re, err := regexp.Compile("(?s)" + value)
+		if err != nil {
+			return false, fmt.Errorf("the regular expression %q can't be evaluated: %w", value, err)
+		}
+		return inverted != re.MatchString(s), nil
+	case "greater", "smaller":
+		msgValue, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return false, nil
+		}
+		ruleValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false, nil
+		}
+		if operator == "greater" {
+			return inverted != (msgValue > ruleValue), nil
+		}
+		return inverted != (msgValue < ruleValue), nil
+	}
+	return false, fmt.Errorf("unknown operator: %s", operator)
+}
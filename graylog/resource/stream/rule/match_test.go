@@ -0,0 +1,52 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	message := map[string]interface{}{
+		"source":           "nginx-1",
+		"http_status":      "502",
+		"message":          "upstream timed out\nwhile reading",
+		"user":             "",
+		"gl2_source_input": "5ea26bb42ab79c0012521000",
+	}
+	data := []struct {
+		title    string
+		operator string
+		field    string
+		value    string
+		inverted bool
+		exp      bool
+	}{
+		{"equals", "equals", "source", "nginx-1", false, true},
+		{"not equals", "equals", "source", "nginx", false, false},
+		{"inverted", "equals", "source", "nginx", true, true},
+		{"missing field", "equals", "host", "nginx", false, false},
+		{"missing field inverted", "equals", "host", "nginx", true, true},
+		{"contains", "contains", "source", "nginx", false, true},
+		{"regex", "regex", "source", "^nginx-[0-9]+$", false, true},
+		{"regex dotall", "regex", "message", "timed out.while", false, true},
+		{"greater", "greater", "http_status", "499", false, true},
+		{"smaller", "smaller", "http_status", "499", false, false},
+		{"greater than a string", "greater", "source", "499", false, false},
+		{"present", "present", "source", "", false, true},
+		{"present empty", "present", "user", "", false, false},
+		{"always_match", "always_match", "", "", true, true},
+		{"match_input", "match_input", "", "5ea26bb42ab79c0012521000", false, true},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			matched, err := Match(d.operator, d.field, d.value, d.inverted, message)
+			require.Nil(t, err)
+			require.Equal(t, d.exp, matched)
+		})
+	}
+
+	_, err := Match("regex", "source", "^(?!nginx)", false, message)
+	require.NotNil(t, err)
+}
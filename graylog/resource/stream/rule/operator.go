@@ -0,0 +1,132 @@
+package rule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyType     = "type"
+	keyOperator = "operator"
+	keyField    = "field"
+	keyValue    = "value"
+)
+
+// Operators maps the operators of stream rules to the stream rule types of Graylog.
+var Operators = map[string]int{
+	"equals":       1,
+	"regex":        2,
+	"greater":      3,
+	"smaller":      4,
+	"present":      5,
+	"contains":     6,
+	"always_match": 7,
+	"match_input":  8,
+}
+
+// OperatorNames returns the sorted names of the operators.
+func OperatorNames() []string {
+	names := make([]string, 0, len(Operators))
+	for name := range Operators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OperatorName returns the operator of the stream rule type.
+func OperatorName(ruleType int) (string, bool) {
+	for name, t := range Operators {
+		if t == ruleType {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Validate checks field and value of a stream rule with the operator.
+// Regular expressions are compiled with Go's RE2 syntax. Graylog uses Java's syntax,
+// so constructs which RE2 doesn't support, such as lookarounds and backreferences, aren't rejected.
+func Validate(operator, field, value string) error {
+	switch operator {
+	case "always_match":
+		return nil
+	case "match_input":
+		if value == "" {
+			return errors.New("value is required for the operator match_input. Set the input ID")
+		}
+		return nil
+	}
+	if field == "" {
+		return fmt.Errorf("field is required for the operator %s", operator)
+	}
+	if operator == "present" {
+		return nil
+	}
+	if value == "" {
+		return fmt.Errorf("value is required for the operator %s", operator)
+	}
+	switch operator {
+	case "greater", "smaller":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("value must be a number for the operator %s: %q", operator, value)
+		}
+	case "regex":
+		if _, err := regexp.Compile(value); err != nil {
+			var syntaxErr *syntax.Error
+			if errors.As(err, &syntaxErr) &&
+				(syntaxErr.Code == syntax.ErrInvalidPerlOp || syntaxErr.Code == syntax.ErrInvalidEscape) {
+				return nil
+			}
+			return fmt.Errorf("value is an invalid regular expression: %w", err)
+		}
+	}
+	return nil
+}
+
+// customizeDiff keeps type and operator in sync, so that changing the configuration
+// from the numeric form to the named form of the same rule type doesn't cause a diff,
+// and validates field and value with the operator.
+func customizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	operator, err := syncOperator(d)
+	if err != nil || operator == "" {
+		return err
+	}
+	if !d.NewValueKnown(keyField) || !d.NewValueKnown(keyValue) {
+		return nil
+	}
+	return Validate(operator, d.Get(keyField).(string), d.Get(keyValue).(string))
+}
+
+// syncOperator sets type from operator if operator is set in the configuration, and otherwise operator from type.
+// It returns the operator, which is empty if it isn't known yet.
+func syncOperator(d *schema.ResourceDiff) (string, error) {
+	operatorKnown, typeKnown := d.NewValueKnown(keyOperator), d.NewValueKnown(keyType)
+	operator, _ := d.Get(keyOperator).(string)
+	switch {
+	case operatorKnown && operator != "" && (!typeKnown || d.HasChange(keyOperator)):
+		if t := Operators[operator]; !typeKnown || d.Get(keyType).(int) != t {
+			return operator, d.SetNew(keyType, t)
+		}
+		return operator, nil
+	case !typeKnown || (!operatorKnown && d.Id() != ""):
+		// type or operator is an unknown value of the configuration
+		return "", nil
+	}
+	t := d.Get(keyType).(int)
+	operator, ok := OperatorName(t)
+	if !ok {
+		return "", fmt.Errorf("type must be one of 1 to %d: %d", len(Operators), t)
+	}
+	if !operatorKnown || d.Get(keyOperator).(string) != operator {
+		return operator, d.SetNew(keyOperator, operator)
+	}
+	return operator, nil
+}
@@ -0,0 +1,128 @@
+package rule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	data := []struct {
+		title    string
+		operator string
+		field    string
+		value    string
+		isErr    bool
+	}{
+		{"equals", "equals", "source", "app", false},
+		{"equals without value", "equals", "source", "", true},
+		{"equals without field", "equals", "", "app", true},
+		{"regex", "regex", "source", "^app-[0-9]+$", false},
+		{"invalid regex", "regex", "source", "^app-[0-9+$", true},
+		{"java lookahead", "regex", "source", "^(?!test).*", false},
+		{"greater", "greater", "http_status", "499", false},
+		{"greater than a string", "greater", "http_status", "five", true},
+		{"present", "present", "user", "", false},
+		{"present without field", "present", "", "", true},
+		{"always_match", "always_match", "", "", false},
+		{"match_input", "match_input", "", "5ea26bb42ab79c0012521287", false},
+		{"match_input without value", "match_input", "", "", true},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			err := Validate(d.operator, d.field, d.value)
+			if d.isErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestOperators(t *testing.T) {
+	for name, ruleType := range Operators {
+		a, ok := OperatorName(ruleType)
+		require.True(t, ok)
+		require.Equal(t, name, a)
+	}
+	_, ok := OperatorName(9)
+	require.False(t, ok)
+}
+
+func TestCustomizeDiff(t *testing.T) {
+	ctx := context.Background()
+	state := &terraform.InstanceState{
+		ID: "5ea26bb42ab79c0012521287/5ea26bb42ab79c0012521299",
+		Attributes: map[string]string{
+			"id":        "5ea26bb42ab79c0012521287/5ea26bb42ab79c0012521299",
+			"stream_id": "5ea26bb42ab79c0012521287",
+			"rule_id":   "5ea26bb42ab79c0012521299",
+			"field":     "source",
+			"value":     "app",
+			"type":      "1",
+			"operator":  "equals",
+			"inverted":  "false",
+		},
+	}
+
+	// create with an operator
+	diff, err := Resource().Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"stream_id": "5ea26bb42ab79c0012521287",
+		"operator":  "regex",
+		"field":     "source",
+		"value":     "^app-",
+	}), nil)
+	require.Nil(t, err)
+	require.Equal(t, "2", diff.Attributes["type"].New)
+
+	// create with a type
+	diff, err = Resource().Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"stream_id": "5ea26bb42ab79c0012521287",
+		"type":      5,
+		"field":     "source",
+	}), nil)
+	require.Nil(t, err)
+	require.Equal(t, "present", diff.Attributes["operator"].New)
+
+	// the numeric form is replaced with the named form of the same rule type
+	diff, err = Resource().Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"stream_id": "5ea26bb42ab79c0012521287",
+		"operator":  "equals",
+		"field":     "source",
+		"value":     "app",
+	}), nil)
+	require.Nil(t, err)
+	require.True(t, diff.Empty(), diff)
+
+	// the operator is changed
+	diff, err = Resource().Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"stream_id": "5ea26bb42ab79c0012521287",
+		"operator":  "contains",
+		"field":     "source",
+		"value":     "app",
+	}), nil)
+	require.Nil(t, err)
+	require.Equal(t, "6", diff.Attributes["type"].New)
+
+	// the type is changed
+	diff, err = Resource().Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"stream_id": "5ea26bb42ab79c0012521287",
+		"type":      6,
+		"field":     "source",
+		"value":     "app",
+	}), nil)
+	require.Nil(t, err)
+	require.Equal(t, "contains", diff.Attributes["operator"].New)
+
+	// value is required
+	_, err = Resource().Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"stream_id": "5ea26bb42ab79c0012521287",
+		"operator":  "regex",
+		"field":     "source",
+	}), nil)
+	require.NotNil(t, err)
+}
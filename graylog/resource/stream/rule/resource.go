@@ -2,7 +2,8 @@ package rule
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 )
 
 func Resource() *schema.Resource {
@@ -12,30 +13,44 @@ func Resource() *schema.Resource {
 		Update: update,
 		Delete: destroy,
 
+		CustomizeDiff: customizeDiff,
+
 		SchemaVersion:  schemaVersion,
 		StateUpgraders: stateUpgraders,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: util.GenStateFunc(keyStreamID, keyRuleID),
+			StateContext: importer.GenStateFunc(importer.Parent(keyStreamID, importer.Stream), importer.ID(keyRuleID)),
 		},
 
 		Schema: map[string]*schema.Schema{
 			// Required
-			"field": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
 			"stream_id": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+
+			// One of type and operator is required
 			"type": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{keyType, keyOperator},
+			},
+			"operator": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{keyType, keyOperator},
+				ValidateFunc: validation.StringInSlice(OperatorNames(), false),
 			},
 
 			// Optional
+			"field": {
+				// field isn't needed for always_match and match_input
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"value": {
 				// value isn't needed for some type of stream rule
 				Type:     schema.TypeString,
@@ -18,11 +18,21 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 	if err != nil {
 		return nil, err
 	}
+	// Graylog only knows the type. operator takes precedence, because type is computed from it.
+	if operator, _ := data[keyOperator].(string); operator != "" {
+		data[keyType] = Operators[operator]
+	}
+	delete(data, keyOperator)
 	return data, nil
 }
 
 func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
 	util.RenameKey(data, keyID, keyRuleID)
+	if t, ok := data[keyType].(float64); ok {
+		if operator, ok := OperatorName(int(t)); ok {
+			data[keyOperator] = operator
+		}
+	}
 	if err := convert.SetResourceData(d, Resource(), data); err != nil {
 		return err
 	}
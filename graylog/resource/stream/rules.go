@@ -0,0 +1,157 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
+)
+
+const (
+	keyRule        = "rule"
+	keyRules       = "rules"
+	keyRuleIDs     = "rule_ids"
+	keyField       = "field"
+	keyOperator    = "operator"
+	keyValue       = "value"
+	keyDescription = "description"
+	keyInverted    = "inverted"
+)
+
+func schemaRule() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				keyOperator: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(rule.OperatorNames(), false),
+				},
+				keyField: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				keyValue: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				keyDescription: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				keyInverted: {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// schemaRuleIDs returns the schema of the IDs of the rules which are managed by the rule blocks.
+// Graylog doesn't store who manages the rules of a stream, so they are tracked in the state.
+func schemaRuleIDs() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(keyRule) {
+		return nil
+	}
+	blocks := d.Get(keyRule).(*schema.Set).List()
+	for _, a := range blocks {
+		r := a.(map[string]interface{})
+		operator, _ := r[keyOperator].(string)
+		field, _ := r[keyField].(string)
+		value, _ := r[keyValue].(string)
+		if err := rule.Validate(operator, field, value); err != nil {
+			return fmt.Errorf("rule (operator: %s, field: %q, value: %q): %w", operator, field, value, err)
+		}
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	if len(blocks) != 0 {
+		if err := checkForeignRules(ctx, d, m, blocks); err != nil {
+			return err
+		}
+	}
+	if d.HasChange(keyRule) {
+		return d.SetNewComputed(keyRuleIDs)
+	}
+	return nil
+}
+
+// checkForeignRules returns an error if the stream has rules which aren't managed by the rule blocks,
+// such as rules of graylog_stream_rule or rules added in the Graylog UI.
+// The check doesn't depend on the other resources of the plan, because the managed rules are tracked in rule_ids.
+func checkForeignRules(ctx context.Context, d *schema.ResourceDiff, m interface{}, blocks []interface{}) error {
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	stream, _, err := cl.Stream.Get(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("failed to get a stream %s to check its rules: %w", d.Id(), err)
+	}
+	rules, _ := stream[keyRules].([]interface{})
+	_, foreign := rule.Split(rules, d.Get(keyRuleIDs).(*schema.Set), rule.Expand(blocks))
+	if len(foreign) == 0 {
+		return nil
+	}
+	ids := make([]string, len(foreign))
+	for i, a := range foreign {
+		ids[i], _ = a.(map[string]interface{})[keyID].(string)
+	}
+	return fmt.Errorf(
+		"the stream %s has rules which aren't managed by its rule blocks: %s. "+
+			"The rules of a stream with rule blocks can't be managed by graylog_stream_rule or added outside Terraform. "+
+			"Delete these rules or add rule blocks which match them",
+		d.Id(), strings.Join(ids, ", "))
+}
+
+// getRuleIDs returns the IDs of the stream rules.
+func getRuleIDs(rules []interface{}) []interface{} {
+	ids := make([]interface{}, 0, len(rules))
+	for _, a := range rules {
+		if id, ok := a.(map[string]interface{})[keyID].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// updateRules reconciles the rules which are managed by the rule blocks and updates rule_ids.
+// The other rules of the stream are kept.
+func updateRules(ctx context.Context, cl client.Client, d *schema.ResourceData) error {
+	stream, _, err := cl.Stream.Get(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("failed to get a stream %s to update its rules: %w", d.Id(), err)
+	}
+	current, _ := stream[keyRules].([]interface{})
+	ruleIDs := d.Get(keyRuleIDs).(*schema.Set)
+	desired := rule.Expand(d.Get(keyRule))
+	managed, _ := rule.Split(current, ruleIDs, desired)
+	if err := rule.Reconcile(ctx, cl, d.Id(), managed, desired); err != nil {
+		return err
+	}
+
+	// get the IDs of the created rules
+	stream, _, err = cl.Stream.Get(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("failed to get a stream %s to get the IDs of its rules: %w", d.Id(), err)
+	}
+	current, _ = stream[keyRules].([]interface{})
+	managed, _ = rule.Split(current, ruleIDs, desired)
+	return d.Set(keyRuleIDs, getRuleIDs(managed))
+}
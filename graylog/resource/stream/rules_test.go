@@ -0,0 +1,374 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/config"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+const streamID = "5ea26bb42ab79c0012521287"
+
+// ruleRoutes returns the routes of the stream rule API which change rules.
+// The created rules get the IDs 5ea26bb42ab79c00125212a0, 5ea26bb42ab79c00125212a1 and so on.
+func ruleRoutes(rules *[]interface{}, calls *[]string) []flute.Route {
+	created := 0
+	return []flute.Route{
+		{
+			Name: "create a stream rule",
+			Matcher: flute.Matcher{
+				Method: "POST",
+				Path:   "/api/streams/" + streamID + "/rules",
+			},
+			Tester: flute.Tester{
+				PartOfHeader: testutil.Header(),
+				Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+					r := map[string]interface{}{}
+					if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+						t.Fatal(err)
+					}
+					r["id"] = fmt.Sprintf("5ea26bb42ab79c00125212a%d", created)
+					created++
+					*rules = append(*rules, r)
+					*calls = append(*calls, "create "+r["id"].(string))
+				},
+			},
+			Response: flute.Response{
+				Base: http.Response{
+					StatusCode: 201,
+				},
+				BodyString: `{}`,
+			},
+		},
+		{
+			Name: "delete a stream rule",
+			Matcher: flute.Matcher{
+				Match: func(req *http.Request) (bool, error) {
+					return req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, "/api/streams/"+streamID+"/rules/"), nil
+				},
+			},
+			Tester: flute.Tester{
+				PartOfHeader: testutil.Header(),
+				Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+					id := strings.TrimPrefix(req.URL.Path, "/api/streams/"+streamID+"/rules/")
+					kept := []interface{}{}
+					for _, a := range *rules {
+						if a.(map[string]interface{})["id"] != id {
+							kept = append(kept, a)
+						}
+					}
+					*rules = kept
+					*calls = append(*calls, "delete "+id)
+				},
+			},
+			Response: flute.Response{
+				Base: http.Response{
+					StatusCode: 204,
+				},
+			},
+		},
+	}
+}
+
+func TestAccStreamRules(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the rules of the stream in Graylog
+	rules := []interface{}{}
+	var calls []string
+
+	resourceURLPath := "/api/streams/" + streamID
+	resourceName := "graylog_stream.test"
+
+	streamBody := func() (string, error) {
+		b, err := json.Marshal(map[string]interface{}{
+			"id":                                 streamID,
+			"title":                              "test",
+			"index_set_id":                       "5e9861442ab79c0012e7d1c4",
+			"matching_type":                      "AND",
+			"description":                        "",
+			"disabled":                           false,
+			"remove_matches_from_default_stream": false,
+			"is_default":                         false,
+			"rules":                              rules,
+		})
+		return string(b), err
+	}
+
+	getRoute := flute.Route{
+		Name: "get a stream",
+		Matcher: flute.Matcher{
+			Method: "GET",
+			Path:   resourceURLPath,
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				body, err := streamBody()
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+				}, nil
+			},
+		},
+	}
+
+	postRoute := flute.Route{
+		Name: "create a stream",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/streams",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				body := map[string]interface{}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				// the rules are created with the stream
+				entity := body["entity"].(map[string]interface{})
+				require.Equal(t, []interface{}{
+					map[string]interface{}{"type": float64(1), "field": "source", "value": "app", "description": "", "inverted": false},
+				}, entity["rules"])
+				for i, a := range entity["rules"].([]interface{}) {
+					r := a.(map[string]interface{})
+					r["id"] = fmt.Sprintf("5ea26bb42ab79c001252128%d", 8+i)
+					rules = append(rules, r)
+				}
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+			BodyString: `{
+  "stream_id": "5ea26bb42ab79c0012521287"
+}`,
+		},
+	}
+
+	resumeRoute := flute.Route{
+		Name: "resume a stream",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   resourceURLPath + "/resume",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 204,
+			},
+		},
+	}
+
+	updateRoute := flute.Route{
+		Name: "update a stream",
+		Matcher: flute.Matcher{
+			Method: "PUT",
+			Path:   resourceURLPath,
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				body, err := streamBody()
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+				}, nil
+			},
+		},
+	}
+
+	deleteRoute := flute.Route{
+		Name: "delete a stream",
+		Matcher: flute.Matcher{
+			Method: "DELETE",
+			Path:   resourceURLPath,
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 204,
+			},
+		},
+	}
+
+	routes := func() []flute.Route {
+		return append([]flute.Route{getRoute, postRoute, resumeRoute, updateRoute, deleteRoute}, ruleRoutes(&rules, &calls)...)
+	}
+
+	checkCalls := func(exp ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if strings.Join(exp, ",") != strings.Join(calls, ",") {
+				return fmt.Errorf("the rules should be changed with %v, but %v", exp, calls)
+			}
+			calls = nil
+			return nil
+		}
+	}
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, routes()...)
+		},
+		Config: `
+resource "graylog_stream" "test" {
+  title         = "test"
+  index_set_id  = "5e9861442ab79c0012e7d1c4"
+  matching_type = "AND"
+
+  rule {
+    operator = "equals"
+    field    = "source"
+    value    = "app"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "rule.#", "1"),
+			resource.TestCheckResourceAttr(resourceName, "rule_ids.#", "1"),
+			checkCalls(),
+		),
+	}
+
+	updateStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, routes()...)
+		},
+		// the kept rule isn't recreated, and the new rule is created before the removed rule is deleted
+		Config: `
+resource "graylog_stream" "test" {
+  title         = "test"
+  index_set_id  = "5e9861442ab79c0012e7d1c4"
+  matching_type = "AND"
+
+  rule {
+    operator = "equals"
+    field    = "source"
+    value    = "app"
+  }
+
+  rule {
+    operator    = "contains"
+    field       = "message"
+    value       = "error"
+    description = "errors"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "rule.#", "2"),
+			resource.TestCheckResourceAttr(resourceName, "rule_ids.#", "2"),
+			checkCalls("create 5ea26bb42ab79c00125212a0"),
+		),
+	}
+
+	foreignRuleStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			// a rule is added to the stream by graylog_stream_rule or in the Graylog UI
+			rules = append(rules, map[string]interface{}{
+				"id": "5ea26bb42ab79c00125212ff", "type": 5, "field": "user", "value": "", "inverted": false,
+			})
+			testutil.SetHTTPClient(t, routes()...)
+		},
+		Config:      updateStep.Config,
+		ExpectError: regexp.MustCompile("the stream 5ea26bb42ab79c0012521287 has rules which aren't managed by its rule blocks: 5ea26bb42ab79c00125212ff"),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_stream", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			updateStep,
+			foreignRuleStep,
+		},
+	})
+}
+
+func TestSplitRules(t *testing.T) {
+	rules := []interface{}{
+		// managed, but changed in the Graylog UI
+		map[string]interface{}{"id": "5ea26bb42ab79c0012521288", "type": float64(1), "field": "source", "value": "web", "inverted": false},
+		// matches a rule block which has no managed rule
+		map[string]interface{}{"id": "5ea26bb42ab79c0012521289", "type": float64(1), "field": "source", "value": "app", "inverted": false},
+		// duplicate of the previous rule
+		map[string]interface{}{"id": "5ea26bb42ab79c001252128a", "type": float64(1), "field": "source", "value": "app", "inverted": false},
+		map[string]interface{}{"id": "5ea26bb42ab79c001252128b", "type": float64(5), "field": "user", "value": "", "inverted": false},
+	}
+	ruleIDs := schema.NewSet(schema.HashString, []interface{}{"5ea26bb42ab79c0012521288"})
+	desired := rule.Expand([]interface{}{
+		map[string]interface{}{"operator": "equals", "field": "source", "value": "app", "description": "", "inverted": false},
+	})
+	managed, foreign := rule.Split(rules, ruleIDs, desired)
+	require.Equal(t, []interface{}{"5ea26bb42ab79c0012521288", "5ea26bb42ab79c0012521289"}, getRuleIDs(managed))
+	require.Equal(t, []interface{}{"5ea26bb42ab79c001252128a", "5ea26bb42ab79c001252128b"}, getRuleIDs(foreign))
+}
+
+func TestReconcileRules(t *testing.T) {
+	rules := []interface{}{}
+	var calls []string
+	testutil.SetHTTPClient(t, ruleRoutes(&rules, &calls)...)
+	cl, err := client.New(config.Config{Endpoint: "http://example.com/api", AuthName: "admin", AuthPassword: "admin"})
+	require.Nil(t, err)
+
+	current := []interface{}{
+		map[string]interface{}{"id": "5ea26bb42ab79c0012521288", "type": float64(1), "field": "source", "value": "app", "inverted": false, "description": nil},
+		map[string]interface{}{"id": "5ea26bb42ab79c0012521289", "type": float64(5), "field": "user", "value": "", "inverted": false},
+	}
+	desired := rule.Expand([]interface{}{
+		map[string]interface{}{"operator": "equals", "field": "source", "value": "app", "description": "", "inverted": false},
+		map[string]interface{}{"operator": "contains", "field": "message", "value": "error", "description": "", "inverted": false},
+	})
+	require.Nil(t, rule.Reconcile(context.Background(), cl, streamID, current, desired))
+	require.Equal(t, []string{
+		"create 5ea26bb42ab79c00125212a0",
+		"delete 5ea26bb42ab79c0012521289",
+	}, calls)
+	require.Equal(t, float64(6), rules[0].(map[string]interface{})["type"])
+	require.Equal(t, "error", rules[0].(map[string]interface{})["value"])
+}
+
+func TestCustomizeDiffRules(t *testing.T) {
+	_, err := Resource().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"title":        "test",
+		"index_set_id": "5e9861442ab79c0012e7d1c4",
+		"rule": []interface{}{
+			map[string]interface{}{"operator": "greater", "field": "http_status", "value": "five"},
+		},
+	}), nil)
+	require.NotNil(t, err)
+}
@@ -33,6 +33,12 @@ func update(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("failed to update a stream %s: %w", d.Id(), err)
 	}
 
+	if d.HasChange(keyRule) {
+		if err := updateRules(ctx, cl, d); err != nil {
+			return err
+		}
+	}
+
 	if !d.HasChange(keyDisabled) {
 		return nil
 	}
@@ -3,6 +3,7 @@ package stream
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
 )
 
 const (
@@ -22,6 +23,8 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 	delete(data, keyCreatedAt)
 	delete(data, keyCreatorUserID)
 	delete(data, keyIsDefault)
+	delete(data, keyRule)
+	delete(data, keyRuleIDs)
 	return data, nil
 }
 
@@ -29,6 +32,19 @@ func setDataToResourceData(d *schema.ResourceData, data map[string]interface{})
 	if err := convert.SetResourceData(d, Resource(), data); err != nil {
 		return err
 	}
+	// The rules are tracked only if the stream manages them with rule blocks,
+	// so that the rules of graylog_stream_rule aren't shown as a diff.
+	// The rules which aren't managed by the rule blocks are rejected by customizeDiff.
+	if blocks := d.Get(keyRule).(*schema.Set); blocks.Len() != 0 {
+		rules, _ := data[keyRules].([]interface{})
+		managed, _ := rule.Split(rules, d.Get(keyRuleIDs).(*schema.Set), rule.Expand(blocks))
+		if err := d.Set(keyRule, rule.Flatten(managed)); err != nil {
+			return err
+		}
+		if err := d.Set(keyRuleIDs, getRuleIDs(managed)); err != nil {
+			return err
+		}
+	}
 
 	d.SetId(data[keyID].(string))
 	return nil
@@ -0,0 +1,138 @@
+package clusterconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"class": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				ValidateFunc:     util.ValidateIsJSON,
+			},
+		},
+	}
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	class := d.Get("class").(string)
+	if err := update(ctx, m, class, d.Get("config").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(class)
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The class is the resource ID. class is empty on import.
+	class := d.Id()
+	data, resp, err := cl.ClusterConfig.Get(ctx, class)
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get the cluster configuration %s: %w", class, err)))
+	}
+
+	// Only the keys in the configuration are compared, because Graylog returns all keys with their default values.
+	// All keys are set on import.
+	if s, ok := d.Get("config").(string); ok && s != "" {
+		managed, err := convert.StringJSONToData(s)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data = filterKeys(data, managed)
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to marshal the cluster configuration %s as JSON: %w", class, err))
+	}
+
+	if err := d.Set("class", class); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("config", string(b)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := update(ctx, m, d.Id(), d.Get("config").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceRead(ctx, d, m)
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := cl.ClusterConfig.Delete(ctx, d.Id()); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete the cluster configuration %s: %w", d.Id(), err))
+	}
+	return nil
+}
+
+// update merges the top level keys of the JSON config into the current cluster configuration of the class
+// and stores the configuration.
+func update(ctx context.Context, m interface{}, class, config string) error {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return err
+	}
+	managed, err := convert.StringJSONToData(config)
+	if err != nil {
+		return err
+	}
+	data, _, err := cl.ClusterConfig.Get(ctx, class)
+	if err != nil {
+		return fmt.Errorf("failed to get the cluster configuration %s: %w", class, err)
+	}
+	for k, v := range managed {
+		data[k] = v
+	}
+	if _, err := cl.ClusterConfig.Update(ctx, class, data); err != nil {
+		return fmt.Errorf("failed to update the cluster configuration %s: %w", class, err)
+	}
+	return nil
+}
+
+func filterKeys(data, keys map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(keys))
+	for k := range keys {
+		if v, ok := data[k]; ok {
+			ret[k] = v
+		}
+	}
+	return ret
+}
@@ -0,0 +1,197 @@
+package clusterconfig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+const resourceURLPath = "/api/system/cluster_config/org.graylog2.indexer.searches.SearchesClusterConfig"
+
+// routes returns the routes of the cluster configuration API which store the configuration in body.
+// Graylog returns 204 if the configuration isn't stored.
+func routes(body *string, testPut func(t *testing.T, data map[string]interface{})) []flute.Route {
+	return []flute.Route{
+		{
+			Name: "get a cluster configuration",
+			Matcher: flute.Matcher{
+				Method: "GET",
+			},
+			Tester: flute.Tester{
+				Path:         resourceURLPath,
+				PartOfHeader: testutil.Header(),
+			},
+			Response: flute.Response{
+				Response: func(req *http.Request) (*http.Response, error) {
+					if *body == "" {
+						return &http.Response{
+							StatusCode: 204,
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+						}, nil
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(*body)),
+					}, nil
+				},
+			},
+		},
+		{
+			Name: "update a cluster configuration",
+			Matcher: flute.Matcher{
+				Method: "PUT",
+			},
+			Tester: flute.Tester{
+				Path:         resourceURLPath,
+				PartOfHeader: testutil.Header(),
+				Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+					b, err := ioutil.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					data := map[string]interface{}{}
+					if err := json.Unmarshal(b, &data); err != nil {
+						t.Fatal(err)
+					}
+					testPut(t, data)
+					*body = string(b)
+				},
+			},
+			Response: flute.Response{
+				Base: http.Response{
+					StatusCode: 202,
+				},
+			},
+		},
+		{
+			Name: "delete a cluster configuration",
+			Matcher: flute.Matcher{
+				Method: "DELETE",
+			},
+			Tester: flute.Tester{
+				Path:         resourceURLPath,
+				PartOfHeader: testutil.Header(),
+				Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+					*body = ""
+				},
+			},
+			Response: flute.Response{
+				Base: http.Response{
+					StatusCode: 202,
+				},
+			},
+		},
+	}
+}
+
+func TestAccClusterConfig(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{
+  "query_time_range_limit": "PT0S",
+  "relative_timerange_options": {
+    "PT5M": "Search in the last 5 minutes"
+  }
+}`
+	resourceName := "graylog_cluster_config.test"
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, routes(&body, func(t *testing.T, data map[string]interface{}) {
+				// the keys which aren't in config are kept
+				require.Equal(t, map[string]interface{}{
+					"query_time_range_limit": "P30D",
+					"relative_timerange_options": map[string]interface{}{
+						"PT5M": "Search in the last 5 minutes",
+					},
+				}, data)
+			})...)
+		},
+		Config: `
+resource "graylog_cluster_config" "test" {
+  class = "org.graylog2.indexer.searches.SearchesClusterConfig"
+  config = jsonencode({
+    query_time_range_limit = "P30D"
+  })
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", "org.graylog2.indexer.searches.SearchesClusterConfig"),
+			resource.TestCheckResourceAttr(resourceName, "config", `{"query_time_range_limit":"P30D"}`),
+		),
+	}
+
+	updateStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, routes(&body, func(t *testing.T, data map[string]interface{}) {
+				require.Equal(t, "P7D", data["query_time_range_limit"])
+				require.Contains(t, data, "relative_timerange_options")
+			})...)
+		},
+		Config: `
+resource "graylog_cluster_config" "test" {
+  class = "org.graylog2.indexer.searches.SearchesClusterConfig"
+  config = jsonencode({
+    query_time_range_limit = "P7D"
+  })
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "config", `{"query_time_range_limit":"P7D"}`),
+		),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_cluster_config", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			updateStep,
+		},
+	})
+}
+
+func TestAccClusterConfigNoContent(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the configuration isn't stored
+	body := ""
+	resourceName := "graylog_cluster_config.test"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_cluster_config", Resource()),
+		Steps: []resource.TestStep{
+			{
+				ResourceName: resourceName,
+				PreConfig: func() {
+					testutil.SetHTTPClient(t, routes(&body, func(t *testing.T, data map[string]interface{}) {
+						require.Equal(t, map[string]interface{}{"query_time_range_limit": "P30D"}, data)
+					})...)
+				},
+				Config: `
+resource "graylog_cluster_config" "test" {
+  class = "org.graylog2.indexer.searches.SearchesClusterConfig"
+  config = jsonencode({
+    query_time_range_limit = "P30D"
+  })
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config", `{"query_time_range_limit":"P30D"}`),
+				),
+			},
+		},
+	})
+}
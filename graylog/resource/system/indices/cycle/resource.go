@@ -0,0 +1,115 @@
+package cycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	// deflectorTimeout is how long to wait for the deflector to point to the new index
+	deflectorTimeout = 30 * time.Second
+	// deflectorPollInterval is how often to check the deflector status
+	deflectorPollInterval = 500 * time.Millisecond
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		DeleteContext: resourceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"index_set_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cycle_deflector": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+			"rebuild_index_ranges": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+			"current_target": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	indexSetID := d.Get("index_set_id").(string)
+
+	if d.Get("cycle_deflector").(bool) {
+		// The index ranges are rebuilt after the new index becomes the write index
+		before, _, err := cl.IndexSet.GetDeflectorStatus(ctx, indexSetID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to get the deflector status of the index set %s: %w", indexSetID, err))
+		}
+		if _, err := cl.IndexSet.Cycle(ctx, indexSetID); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to cycle the deflector of the index set %s: %w", indexSetID, err))
+		}
+		if _, err := cl.IndexSet.WaitForCycle(
+			ctx, indexSetID, before.CurrentTarget, deflectorTimeout, deflectorPollInterval); err != nil {
+			return diag.FromErr(fmt.Errorf("deflector of the index set %s was cycled but isn't ready: %w", indexSetID, err))
+		}
+	}
+
+	if d.Get("rebuild_index_ranges").(bool) {
+		if _, err := cl.IndexSet.RebuildIndexRanges(ctx, indexSetID); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to rebuild the index ranges of the index set %s: %w", indexSetID, err))
+		}
+	}
+
+	d.SetId(indexSetID)
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The cycle itself has no state in Graylog. The resource is removed if the index set is deleted.
+	indexSetID := d.Get("index_set_id").(string)
+	status, resp, err := cl.IndexSet.GetDeflectorStatus(ctx, indexSetID)
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get the deflector status of the index set %s: %w", indexSetID, err)))
+	}
+	if err := d.Set("current_target", status.CurrentTarget); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Nothing to do in Graylog. The rotated indices and rebuilt ranges are kept.
+	d.SetId("")
+	return nil
+}
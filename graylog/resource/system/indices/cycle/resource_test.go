@@ -0,0 +1,145 @@
+package cycle
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestAccIndexSetCycle(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	deflectorBody := `{
+  "is_up": true,
+  "current_target": "graylog_0"
+}`
+
+	pendingCycle := 0
+
+	indexSetID := "5ea25a282ab79c00125200b9"
+	resourceName := "graylog_index_set_cycle.test"
+
+	getRoute := flute.Route{
+		Name: "get the deflector status",
+		Matcher: flute.Matcher{
+			Method: "GET",
+		},
+		Tester: flute.Tester{
+			Path:         "/api/system/deflector/" + indexSetID,
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				if pendingCycle > 0 {
+					// Graylog switches the deflector to the new index asynchronously
+					pendingCycle--
+					if pendingCycle == 0 {
+						deflectorBody = `{
+  "is_up": true,
+  "current_target": "graylog_1"
+}`
+					}
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(deflectorBody)),
+				}, nil
+			},
+		},
+	}
+
+	cycleRoute := flute.Route{
+		Name: "cycle the deflector",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/system/deflector/" + indexSetID + "/cycle",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				pendingCycle = 2
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 202,
+			},
+		},
+	}
+
+	rebuildRoute := flute.Route{
+		Name: "rebuild the index ranges",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/system/indices/ranges/index_set/" + indexSetID + "/rebuild",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				if !strings.Contains(deflectorBody, "graylog_1") {
+					t.Error("the index ranges should be rebuilt after the deflector points to the new index")
+				}
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 202,
+			},
+		},
+	}
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, cycleRoute, rebuildRoute)
+		},
+		Config: `
+resource "graylog_index_set_cycle" "test" {
+  index_set_id = "5ea25a282ab79c00125200b9"
+  triggers = {
+    shards = "4"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", indexSetID),
+			resource.TestCheckResourceAttr(resourceName, "current_target", "graylog_1"),
+		),
+	}
+
+	rebuildStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			// the deflector isn't cycled
+			testutil.SetHTTPClient(t, getRoute, rebuildRoute)
+		},
+		Config: `
+resource "graylog_index_set_cycle" "test" {
+  index_set_id    = "5ea25a282ab79c00125200b9"
+  cycle_deflector = false
+  triggers = {
+    shards = "5"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "cycle_deflector", "false"),
+			resource.TestCheckResourceAttr(resourceName, "current_target", "graylog_1"),
+		),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_index_set_cycle", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			rebuildStep,
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
 	ftClient "github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/fieldtype"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 )
 
 func Resource() *schema.Resource {
@@ -19,7 +20,7 @@ func Resource() *schema.Resource {
 		DeleteContext: resourceDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.GenStateFunc(importer.Parent("index_set_id", importer.IndexSet), importer.ID("field")),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -34,12 +35,9 @@ func Resource() *schema.Resource {
 				ForceNew: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"string", "string_fts", "long", "double", "date",
-					"boolean", "binary", "ip", "geo-point",
-				}, false),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(ftClient.Types, false),
 			},
 			"rotate": {
 				Type:     schema.TypeBool,
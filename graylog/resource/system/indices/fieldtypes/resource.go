@@ -0,0 +1,222 @@
+package fieldtypes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	ftClient "github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/fieldtype"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const perPage = 100
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importer.StateFunc(importer.IndexSet),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"index_set_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"field_types": {
+				Type:         schema.TypeMap,
+				Required:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateFieldTypes,
+			},
+			"rotate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func validateFieldTypes(v interface{}, k string) ([]string, []error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be map", k)}
+	}
+	var errs []error
+	for field, a := range m {
+		t, _ := a.(string)
+		if !isValidType(t) {
+			errs = append(errs, fmt.Errorf("the type of the field %q in %s must be one of %v, got %q", field, k, ftClient.Types, t))
+		}
+	}
+	return nil, errs
+}
+
+func isValidType(t string) bool {
+	for _, a := range ftClient.Types {
+		if a == t {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	indexSetID := d.Get("index_set_id").(string)
+	if err := apply(ctx, m, indexSetID, nil, toStringMap(d.Get("field_types")), d.Get("rotate").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(indexSetID)
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The index set ID is the resource ID. index_set_id is empty on import.
+	indexSetID := d.Id()
+
+	fieldTypes, resp, err := getCustomFieldTypes(ctx, cl, indexSetID)
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get the field types of the index set %s: %w", indexSetID, err)))
+	}
+	if err := d.Set("index_set_id", indexSetID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("field_types", fieldTypes); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	oldV, newV := d.GetChange("field_types")
+	if err := apply(ctx, m, d.Id(), toStringMap(oldV), toStringMap(newV), d.Get("rotate").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceRead(ctx, d, m)
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fields := sortedKeys(toStringMap(d.Get("field_types")))
+	if len(fields) == 0 {
+		return nil
+	}
+	if _, err := cl.FieldType.RemoveCustomMapping(ctx, ftClient.CustomFieldMappingRemovalRequest{
+		Fields:    fields,
+		IndexSets: []string{d.Id()},
+		Rotate:    d.Get("rotate").(bool),
+	}); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to remove the custom field type mappings of the index set %s: %w", d.Id(), err))
+	}
+	return nil
+}
+
+// apply changes the custom field type mappings of the index set from oldTypes to newTypes.
+// Each change is requested without rotation, then the deflector is cycled once if rotate is true,
+// so the changes are applied with a single rotation.
+func apply(ctx context.Context, m interface{}, indexSetID string, oldTypes, newTypes map[string]string, rotate bool) error {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return err
+	}
+	changed := false
+
+	var removed []string
+	for _, field := range sortedKeys(oldTypes) {
+		if _, ok := newTypes[field]; !ok {
+			removed = append(removed, field)
+		}
+	}
+	if len(removed) != 0 {
+		if _, err := cl.FieldType.RemoveCustomMapping(ctx, ftClient.CustomFieldMappingRemovalRequest{
+			Fields:    removed,
+			IndexSets: []string{indexSetID},
+		}); err != nil {
+			return fmt.Errorf("failed to remove the custom field type mappings %v of the index set %s: %w", removed, indexSetID, err)
+		}
+		changed = true
+	}
+
+	for _, field := range sortedKeys(newTypes) {
+		t := newTypes[field]
+		if old, ok := oldTypes[field]; ok && old == t {
+			continue
+		}
+		if _, err := cl.FieldType.ChangeFieldType(ctx, ftClient.FieldTypeChangeRequest{
+			Field:     field,
+			Type:      t,
+			IndexSets: []string{indexSetID},
+		}); err != nil {
+			return fmt.Errorf("failed to set the type of the field %s of the index set %s: %w", field, indexSetID, err)
+		}
+		changed = true
+	}
+
+	if !changed || !rotate {
+		return nil
+	}
+	if _, err := cl.IndexSet.Cycle(ctx, indexSetID); err != nil {
+		return fmt.Errorf("failed to cycle the deflector of the index set %s: %w", indexSetID, err)
+	}
+	return nil
+}
+
+// getCustomFieldTypes returns the custom field type mappings of the index set.
+func getCustomFieldTypes(ctx context.Context, cl clientPkg.Client, indexSetID string) (map[string]interface{}, *http.Response, error) {
+	ret := map[string]interface{}{}
+	var resp *http.Response
+	elements, err := util.GetAllPages(perPage, func(page int) (map[string]interface{}, error) {
+		body, r, err := cl.FieldType.GetFieldTypes(ctx, indexSetID, &ftClient.GetFieldTypesParams{Page: page, PerPage: perPage})
+		resp = r
+		return body, err
+	}, "elements")
+	if err != nil {
+		return nil, resp, err
+	}
+	for _, elem := range elements {
+		if origin, _ := elem["origin"].(string); origin != ftClient.OriginOverriddenIndex {
+			continue
+		}
+		field, _ := elem["field_name"].(string)
+		ret[field] = elem["type"]
+	}
+	return ret, resp, nil
+}
+
+func toStringMap(v interface{}) map[string]string {
+	m, _ := v.(map[string]interface{})
+	ret := make(map[string]string, len(m))
+	for k, a := range m {
+		ret[k], _ = a.(string)
+	}
+	return ret
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
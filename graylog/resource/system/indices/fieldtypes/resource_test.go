@@ -0,0 +1,199 @@
+package fieldtypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestAccIndexSetFieldTypes(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	indexSetID := "5ea25a282ab79c00125200b9"
+	resourceName := "graylog_index_set_field_types.test"
+
+	// the custom field types of the index set in Graylog
+	fieldTypes := map[string]string{}
+	cycles := 0
+
+	getRoute := flute.Route{
+		Name: "get the field types of an index set",
+		Matcher: flute.Matcher{
+			Method: "GET",
+		},
+		Tester: flute.Tester{
+			Path:         "/api/system/indices/index_sets/types/" + indexSetID,
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				elements := []interface{}{
+					map[string]interface{}{"field_name": "message", "type": "string_fts", "origin": "INDEX"},
+					map[string]interface{}{"field_name": "user", "type": "string", "origin": "OVERRIDDEN_PROFILE"},
+				}
+				for field, typ := range fieldTypes {
+					elements = append(elements, map[string]interface{}{
+						"field_name": field, "type": typ, "origin": "OVERRIDDEN_INDEX",
+					})
+				}
+				b, err := json.Marshal(map[string]interface{}{"total": len(elements), "elements": elements})
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(string(b))),
+				}, nil
+			},
+		},
+	}
+
+	changeRoute := flute.Route{
+		Name: "change the type of a field",
+		Matcher: flute.Matcher{
+			Method: "PUT",
+			Path:   "/api/system/indices/mappings",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				body := map[string]interface{}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				// the index set is rotated once after all changes
+				require.Equal(t, false, body["rotate"])
+				require.Equal(t, []interface{}{indexSetID}, body["index_sets"])
+				fieldTypes[body["field"].(string)] = body["type"].(string)
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+		},
+	}
+
+	removeRoute := flute.Route{
+		Name: "remove custom field type mappings",
+		Matcher: flute.Matcher{
+			Method: "PUT",
+			Path:   "/api/system/indices/mappings/remove_mapping",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				body := map[string]interface{}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				for _, field := range body["fields"].([]interface{}) {
+					delete(fieldTypes, field.(string))
+				}
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+		},
+	}
+
+	cycleRoute := flute.Route{
+		Name: "cycle the deflector",
+		Matcher: flute.Matcher{
+			Method: "POST",
+			Path:   "/api/system/deflector/" + indexSetID + "/cycle",
+		},
+		Tester: flute.Tester{
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				cycles++
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 200,
+			},
+		},
+	}
+
+	checkCycles := func(exp int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if cycles != exp {
+				return fmt.Errorf("the deflector should be cycled %d times, but cycled %d times", exp, cycles)
+			}
+			return nil
+		}
+	}
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, changeRoute, removeRoute, cycleRoute)
+		},
+		Config: `
+resource "graylog_index_set_field_types" "test" {
+  index_set_id = "5ea25a282ab79c00125200b9"
+  field_types = {
+    http_status = "long"
+    client_ip   = "ip"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "field_types.%", "2"),
+			resource.TestCheckResourceAttr(resourceName, "field_types.http_status", "long"),
+			resource.TestCheckResourceAttr(resourceName, "field_types.client_ip", "ip"),
+			checkCycles(1),
+		),
+	}
+
+	updateStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, changeRoute, removeRoute, cycleRoute)
+		},
+		Config: `
+resource "graylog_index_set_field_types" "test" {
+  index_set_id = "5ea25a282ab79c00125200b9"
+  field_types = {
+    http_status = "string"
+    bytes       = "long"
+  }
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "field_types.%", "2"),
+			resource.TestCheckResourceAttr(resourceName, "field_types.http_status", "string"),
+			resource.TestCheckResourceAttr(resourceName, "field_types.bytes", "long"),
+			checkCycles(2),
+		),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_index_set_field_types", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			updateStep,
+		},
+	})
+}
+
+func TestValidateFieldTypes(t *testing.T) {
+	_, errs := validateFieldTypes(map[string]interface{}{"http_status": "long", "client_ip": "ip"}, "field_types")
+	require.Empty(t, errs)
+	_, errs = validateFieldTypes(map[string]interface{}{"http_status": "integer"}, "field_types")
+	require.Len(t, errs, 1)
+}
@@ -2,6 +2,7 @@ package indexset
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -16,7 +17,7 @@ func Resource() *schema.Resource {
 		StateUpgraders: stateUpgraders,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.IndexSet),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -49,6 +50,8 @@ func Resource() *schema.Resource {
 				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
+			keyRotation:  schemaRotation(),
+			keyRetention: schemaRetention(),
 			"index_analyzer": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -110,20 +113,15 @@ func Resource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"data_tiering": {
-				Type:             schema.TypeString,
-				Optional:         true,
-				Default:          "{\"type\":\"hot_only\",\"index_lifetime_min\":\"P30D\",\"index_lifetime_max\":\"P40D\"}",
-				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
-				ValidateFunc:     util.ValidateIsJSON,
-			},
-			"field_restrictions": {
+			keyDataTiering: schemaDataTiering(),
+			keyFieldRestrictions: {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Default:          "{}",
 				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
+			keyFieldRestriction: schemaFieldRestriction(),
 		},
 	}
 }
@@ -0,0 +1,97 @@
+package indexset
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyFieldRestriction  = "field_restriction"
+	keyFieldRestrictions = "field_restrictions"
+)
+
+// restrictionTypes are the types of the field restrictions of Graylog 6.
+var restrictionTypes = []string{"immutable", "hidden"}
+
+// templateFields are the fields of the index set configuration of index set templates,
+// which can be restricted.
+var templateFields = []string{
+	"shards",
+	"replicas",
+	"index_analyzer",
+	"index_optimization_max_num_segments",
+	"index_optimization_disabled",
+	"field_type_refresh_interval",
+	"use_legacy_rotation",
+	"rotation_strategy_class",
+	"rotation_strategy",
+	"retention_strategy_class",
+	"retention_strategy",
+	"data_tiering",
+}
+
+func schemaFieldRestriction() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeSet,
+		Optional:      true,
+		ConflictsWith: []string{keyFieldRestrictions},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"field": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(templateFields, false),
+				},
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(restrictionTypes, false),
+				},
+			},
+		},
+	}
+}
+
+// expandFieldRestrictions renders the field_restriction blocks to the payload of field_restrictions,
+// which maps the fields to the lists of restrictions.
+func expandFieldRestrictions(v interface{}) map[string]interface{} {
+	var list []interface{}
+	switch a := v.(type) {
+	case *schema.Set:
+		list = a.List()
+	case []interface{}:
+		list = a
+	}
+	restrictions := map[string]interface{}{}
+	for _, a := range list {
+		elem := a.(map[string]interface{})
+		field := elem["field"].(string)
+		rs, _ := restrictions[field].([]interface{})
+		restrictions[field] = append(rs, map[string]interface{}{"type": elem["type"]})
+	}
+	return restrictions
+}
+
+// flattenFieldRestrictions converts the field_restrictions of Graylog API to the field_restriction blocks.
+func flattenFieldRestrictions(v interface{}) []interface{} {
+	restrictions, _ := v.(map[string]interface{})
+	fields := make([]string, 0, len(restrictions))
+	for field := range restrictions {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	ret := []interface{}{}
+	for _, field := range fields {
+		list, _ := restrictions[field].([]interface{})
+		for _, a := range list {
+			elem, ok := a.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ret = append(ret, map[string]interface{}{"field": field, "type": elem["type"]})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,67 @@
+package indexset
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandFieldRestrictions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Resource().Schema, map[string]interface{}{
+		"title":        "test",
+		"index_prefix": "test",
+		"field_restriction": []interface{}{
+			map[string]interface{}{"field": "shards", "type": "immutable"},
+			map[string]interface{}{"field": "shards", "type": "hidden"},
+			map[string]interface{}{"field": "replicas", "type": "hidden"},
+		},
+	})
+	data, err := getDataFromResourceData(d)
+	require.Nil(t, err)
+	require.NotContains(t, data, "field_restriction")
+	restrictions := data["field_restrictions"].(map[string]interface{})
+	require.Equal(t, []interface{}{map[string]interface{}{"type": "hidden"}}, restrictions["replicas"])
+	require.ElementsMatch(t, []interface{}{
+		map[string]interface{}{"type": "immutable"},
+		map[string]interface{}{"type": "hidden"},
+	}, restrictions["shards"])
+
+	d = schema.TestResourceDataRaw(t, Resource().Schema, map[string]interface{}{
+		"title":              "test",
+		"index_prefix":       "test",
+		"field_restrictions": `{"shards":[{"type":"immutable"}]}`,
+	})
+	data, err = getDataFromResourceData(d)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"shards": []interface{}{map[string]interface{}{"type": "immutable"}},
+	}, data["field_restrictions"])
+}
+
+func TestFlattenFieldRestrictions(t *testing.T) {
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"field": "replicas", "type": "hidden"},
+		map[string]interface{}{"field": "shards", "type": "immutable"},
+		map[string]interface{}{"field": "shards", "type": "hidden"},
+	}, flattenFieldRestrictions(map[string]interface{}{
+		"shards": []interface{}{
+			map[string]interface{}{"type": "immutable"},
+			map[string]interface{}{"type": "hidden"},
+		},
+		"replicas": []interface{}{
+			map[string]interface{}{"type": "hidden"},
+		},
+	}))
+	require.Equal(t, []interface{}{}, flattenFieldRestrictions(nil))
+}
+
+func TestValidateFieldRestriction(t *testing.T) {
+	sc := Resource().Schema[keyFieldRestriction].Elem.(*schema.Resource).Schema
+	_, errs := sc["field"].ValidateFunc("shards", "field")
+	require.Empty(t, errs)
+	_, errs = sc["field"].ValidateFunc("message", "field")
+	require.NotEmpty(t, errs)
+	_, errs = sc["type"].ValidateFunc("readonly", "type")
+	require.NotEmpty(t, errs)
+}
@@ -7,16 +7,28 @@ import (
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
 )
 
-const schemaVersion = 1
+const schemaVersion = 2
 
 var stateUpgraders = []schema.StateUpgrader{
 	stateUpgraderV1,
+	stateUpgraderV2,
 }
 
 func indexsetResourceV0() *schema.Resource {
 	return &schema.Resource{}
 }
 
+func indexsetResourceV1() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			keyDataTiering: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
 var stateUpgraderV1 = schema.StateUpgrader{
 	Version: 0,
 	Type:    indexsetResourceV0().CoreConfigSchema().ImpliedType(),
@@ -27,3 +39,26 @@ var stateUpgraderV1 = schema.StateUpgrader{
 		return rawState, nil
 	},
 }
+
+// stateUpgraderV2 converts the JSON string data_tiering to the data_tiering block.
+var stateUpgraderV2 = schema.StateUpgrader{
+	Version: 1,
+	Type:    indexsetResourceV1().CoreConfigSchema().ImpliedType(),
+	Upgrade: func(_ context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		return upgradeStateV1(rawState)
+	},
+}
+
+func upgradeStateV1(rawState map[string]interface{}) (map[string]interface{}, error) {
+	s, _ := rawState[keyDataTiering].(string)
+	if s == "" {
+		rawState[keyDataTiering] = []interface{}{}
+		return rawState, nil
+	}
+	m, err := convert.StringJSONToData(s)
+	if err != nil {
+		return nil, err
+	}
+	rawState[keyDataTiering] = flattenDataTiering(m)
+	return rawState, nil
+}
@@ -0,0 +1,435 @@
+package indexset
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	rotationStrategyPackage   = "org.graylog2.indexer.rotation.strategies."
+	retentionStrategyPackage  = "org.graylog2.indexer.retention.strategies."
+	archiveRetentionPackage   = "org.graylog.plugins.archive.indexer.retention.strategies."
+	dataTieringHotOnly        = "hot_only"
+	dataTieringHotWarm        = "hot_warm"
+	defaultIndexLifetimeMin   = "P30D"
+	defaultIndexLifetimeMax   = "P40D"
+	defaultRotationPeriod     = "P1D"
+	defaultMaxDocsPerIndex    = 20000000
+	defaultMaxNumberOfIndices = 20
+	defaultArchiveIndexAction = "DELETE"
+)
+
+// strategy is a typed block of rotation or retention and the strategy it's rendered to.
+type strategy struct {
+	class  string
+	config string
+}
+
+// rotationStrategies maps the typed blocks of rotation to the rotation strategies.
+var rotationStrategies = map[string]strategy{
+	"time_based": {
+		class:  rotationStrategyPackage + "TimeBasedRotationStrategy",
+		config: rotationStrategyPackage + "TimeBasedRotationStrategyConfig",
+	},
+	"size_based": {
+		class:  rotationStrategyPackage + "SizeBasedRotationStrategy",
+		config: rotationStrategyPackage + "SizeBasedRotationStrategyConfig",
+	},
+	"message_count": {
+		class:  rotationStrategyPackage + "MessageCountRotationStrategy",
+		config: rotationStrategyPackage + "MessageCountRotationStrategyConfig",
+	},
+	"time_size_optimizing": {
+		class:  rotationStrategyPackage + "TimeBasedSizeOptimizingStrategy",
+		config: rotationStrategyPackage + "TimeBasedSizeOptimizingStrategyConfig",
+	},
+}
+
+// retentionStrategies maps the typed blocks of retention to the retention strategies.
+// archive requires the Graylog Enterprise archive plugin.
+var retentionStrategies = map[string]strategy{
+	"delete": {
+		class:  retentionStrategyPackage + "DeletionRetentionStrategy",
+		config: retentionStrategyPackage + "DeletionRetentionStrategyConfig",
+	},
+	"close": {
+		class:  retentionStrategyPackage + "ClosingRetentionStrategy",
+		config: retentionStrategyPackage + "ClosingRetentionStrategyConfig",
+	},
+	"archive": {
+		class:  archiveRetentionPackage + "ArchiveRetentionStrategy",
+		config: archiveRetentionPackage + "ArchiveRetentionStrategyConfig",
+	},
+	"noop": {
+		class:  retentionStrategyPackage + "NoopRetentionStrategy",
+		config: retentionStrategyPackage + "NoopRetentionStrategyConfig",
+	},
+}
+
+// strategyBlocks are the typed blocks and the attributes of the strategy class and config they're rendered to.
+var strategyBlocks = []struct {
+	key, classKey, configKey string
+	strategies               map[string]strategy
+}{
+	{keyRotation, keyRotationStrategyClass, keyRotationStrategy, rotationStrategies},
+	{keyRetention, keyRetentionStrategyClass, keyRetentionStrategy, retentionStrategies},
+}
+
+// periodPattern matches an ISO-8601 period such as "P1D", "P1W" and "PT12H".
+var periodPattern = regexp.MustCompile(`^P(\d+Y)?(\d+M)?(\d+W)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?$`)
+
+// validatePeriod validates an ISO-8601 period.
+func validatePeriod(v interface{}, k string) ([]string, []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if !periodPattern.MatchString(s) || s == "P" || strings.HasSuffix(s, "T") {
+		return nil, []error{fmt.Errorf(`%s must be an ISO-8601 period such as "P1D" or "PT12H": %q`, k, s)}
+	}
+	return nil, nil
+}
+
+// sizePattern matches a size such as "1073741824", "500MB" and "1GiB".
+var sizePattern = regexp.MustCompile(`^([0-9]+)\s*([KMGT]i?B|B)?$`)
+
+var sizeUnits = map[string]float64{
+	"":    1,
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// parseSize parses a size in bytes, optionally with a decimal (KB, MB, GB, TB) or binary (KiB, MiB, GiB, TiB) unit.
+func parseSize(s string) (int64, error) {
+	m := sizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf(`size must be a number of bytes optionally followed by one of B, KB, MB, GB, TB, KiB, MiB, GiB and TiB: %q`, s)
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the size %q: %w", s, err)
+	}
+	size := n * sizeUnits[m[2]]
+	if size < 1 || size > math.MaxInt64 {
+		return 0, fmt.Errorf("size is out of range: %q", s)
+	}
+	return int64(size), nil
+}
+
+func validateSize(v interface{}, k string) ([]string, []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := parseSize(s); err != nil {
+		return nil, []error{fmt.Errorf("%s is invalid: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// diffSuppressSize suppresses the diff between sizes with different units such as "1GiB" and "1073741824".
+func diffSuppressSize(k, oldV, newV string, d *schema.ResourceData) bool {
+	o, err := parseSize(oldV)
+	if err != nil {
+		return false
+	}
+	n, err := parseSize(newV)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+var schemaMaxNumberOfIndices = &schema.Schema{
+	Type:         schema.TypeInt,
+	Optional:     true,
+	Default:      defaultMaxNumberOfIndices,
+	ValidateFunc: validation.IntAtLeast(1),
+}
+
+func schemaPeriod(def string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      def,
+		ValidateFunc: validatePeriod,
+	}
+}
+
+// strategyBlock returns a typed block of rotation, retention or data_tiering.
+func strategyBlock(attrs map[string]*schema.Schema, exactlyOneOf []string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: exactlyOneOf,
+		Elem:         &schema.Resource{Schema: attrs},
+	}
+}
+
+// exactlyOneOf returns the addresses of the typed blocks in the parent block.
+func exactlyOneOf(parent string, strategies map[string]strategy) []string {
+	names := strategyNames(strategies)
+	for i, name := range names {
+		names[i] = parent + ".0." + name
+	}
+	return names
+}
+
+func strategyNames(strategies map[string]strategy) []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func schemaRotation() *schema.Schema {
+	blocks := exactlyOneOf(keyRotation, rotationStrategies)
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{keyRotationStrategyClass, keyRotationStrategy},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"time_based": strategyBlock(map[string]*schema.Schema{
+					"rotation_period": schemaPeriod(defaultRotationPeriod),
+					"max_rotation_period": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validatePeriod,
+					},
+					"rotate_empty_index_set": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+				}, blocks),
+				"size_based": strategyBlock(map[string]*schema.Schema{
+					keyMaxSize: {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateFunc:     validateSize,
+						DiffSuppressFunc: diffSuppressSize,
+					},
+				}, blocks),
+				"message_count": strategyBlock(map[string]*schema.Schema{
+					"max_docs_per_index": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultMaxDocsPerIndex,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				}, blocks),
+				"time_size_optimizing": strategyBlock(map[string]*schema.Schema{
+					"index_lifetime_min": schemaPeriod(defaultIndexLifetimeMin),
+					"index_lifetime_max": schemaPeriod(defaultIndexLifetimeMax),
+				}, blocks),
+			},
+		},
+	}
+}
+
+func schemaRetention() *schema.Schema {
+	blocks := exactlyOneOf(keyRetention, retentionStrategies)
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{keyRetentionStrategyClass, keyRetentionStrategy},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"delete": strategyBlock(map[string]*schema.Schema{
+					"max_number_of_indices": schemaMaxNumberOfIndices,
+				}, blocks),
+				"close": strategyBlock(map[string]*schema.Schema{
+					"max_number_of_indices": schemaMaxNumberOfIndices,
+				}, blocks),
+				"archive": strategyBlock(map[string]*schema.Schema{
+					"max_number_of_indices": schemaMaxNumberOfIndices,
+					"index_action": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      defaultArchiveIndexAction,
+						ValidateFunc: validation.StringInSlice([]string{"NONE", "CLOSE", "DELETE"}, false),
+					},
+				}, blocks),
+				"noop": strategyBlock(map[string]*schema.Schema{
+					"max_number_of_indices": schemaMaxNumberOfIndices,
+				}, blocks),
+			},
+		},
+	}
+}
+
+func schemaDataTiering() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      dataTieringHotOnly,
+					ValidateFunc: validation.StringInSlice([]string{dataTieringHotOnly, dataTieringHotWarm}, false),
+				},
+				"index_lifetime_min": schemaPeriod(defaultIndexLifetimeMin),
+				"index_lifetime_max": schemaPeriod(defaultIndexLifetimeMax),
+				// hot_warm only
+				"index_hot_lifetime_min": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validatePeriod,
+				},
+				"warm_tier_enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"warm_tier_repository_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"archive_before_deletion": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// getBlock returns the first element of the single block list.
+func getBlock(v interface{}) map[string]interface{} {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil
+	}
+	m, _ := list[0].(map[string]interface{})
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
+
+// expandStrategy renders the typed block set in the rotation or retention block to the strategy class and config.
+// nil is returned if the block isn't set.
+func expandStrategy(v interface{}, strategies map[string]strategy) (string, map[string]interface{}, error) {
+	parent := getBlock(v)
+	if parent == nil {
+		return "", nil, nil
+	}
+	for _, name := range strategyNames(strategies) {
+		block := getBlock(parent[name])
+		if block == nil {
+			continue
+		}
+		st := strategies[name]
+		cfg := map[string]interface{}{"type": st.config}
+		for k, a := range block {
+			if s, ok := a.(string); ok && s == "" {
+				continue
+			}
+			if k == keyMaxSize {
+				size, err := parseSize(a.(string))
+				if err != nil {
+					return "", nil, err
+				}
+				a = size
+			}
+			cfg[k] = a
+		}
+		return st.class, cfg, nil
+	}
+	return "", nil, fmt.Errorf("one of %s must be set", strings.Join(strategyNames(strategies), ", "))
+}
+
+// flattenStrategy converts the strategy class and config to the rotation or retention block sc.
+// nil is returned if the class isn't supported.
+func flattenStrategy(
+	class string, cfg map[string]interface{}, strategies map[string]strategy, sc *schema.Schema,
+) []interface{} {
+	blocks := sc.Elem.(*schema.Resource).Schema
+	for name, st := range strategies {
+		if st.class != class {
+			continue
+		}
+		attrs := blocks[name].Elem.(*schema.Resource).Schema
+		block := make(map[string]interface{}, len(attrs))
+		for k := range attrs {
+			a, ok := cfg[k]
+			if !ok || a == nil {
+				continue
+			}
+			if k == keyMaxSize {
+				if f, ok := a.(float64); ok {
+					a = strconv.FormatInt(int64(f), 10)
+				}
+			}
+			block[k] = a
+		}
+		return []interface{}{map[string]interface{}{name: []interface{}{block}}}
+	}
+	return nil
+}
+
+// expandDataTiering renders the data_tiering block.
+// The hot_only data tiering with the default lifetimes is used if the block isn't set.
+func expandDataTiering(v interface{}) map[string]interface{} {
+	block := getBlock(v)
+	if block == nil {
+		return map[string]interface{}{
+			"type":               dataTieringHotOnly,
+			"index_lifetime_min": defaultIndexLifetimeMin,
+			"index_lifetime_max": defaultIndexLifetimeMax,
+		}
+	}
+	ret := map[string]interface{}{
+		"type":               block["type"],
+		"index_lifetime_min": block["index_lifetime_min"],
+		"index_lifetime_max": block["index_lifetime_max"],
+	}
+	if block["type"] != dataTieringHotWarm {
+		return ret
+	}
+	for _, k := range []string{"index_hot_lifetime_min", "warm_tier_enabled", "warm_tier_repository_name", "archive_before_deletion"} {
+		if s, ok := block[k].(string); ok && s == "" {
+			continue
+		}
+		ret[k] = block[k]
+	}
+	return ret
+}
+
+// flattenDataTiering converts the data tiering of Graylog API to the data_tiering block.
+func flattenDataTiering(v interface{}) []interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return []interface{}{}
+	}
+	attrs := schemaDataTiering().Elem.(*schema.Resource).Schema
+	block := make(map[string]interface{}, len(attrs))
+	for k := range attrs {
+		if a, ok := m[k]; ok && a != nil {
+			block[k] = a
+		}
+	}
+	return []interface{}{block}
+}
@@ -0,0 +1,191 @@
+package indexset
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	data := map[string]int64{
+		"1073741824": 1073741824,
+		"500MB":      500000000,
+		"1GiB":       1073741824,
+		"2 TB":       2000000000000,
+		"10B":        10,
+	}
+	for s, exp := range data {
+		size, err := parseSize(s)
+		require.Nil(t, err, s)
+		require.Equal(t, exp, size, s)
+	}
+	for _, s := range []string{"", "0", "1.5GB", "1gb", "GB", "-1"} {
+		_, err := parseSize(s)
+		require.NotNil(t, err, s)
+	}
+}
+
+func TestValidatePeriod(t *testing.T) {
+	for _, s := range []string{"P1D", "P1W", "PT12H", "P1DT12H", "P1Y2M", "PT30M"} {
+		_, errs := validatePeriod(s, "rotation_period")
+		require.Empty(t, errs, s)
+	}
+	for _, s := range []string{"", "P", "PT", "1D", "P1H", "P1DT", "p1d"} {
+		_, errs := validatePeriod(s, "rotation_period")
+		require.NotEmpty(t, errs, s)
+	}
+}
+
+func TestExpandStrategy(t *testing.T) {
+	class, cfg, err := expandStrategy([]interface{}{
+		map[string]interface{}{
+			"size_based": []interface{}{
+				map[string]interface{}{"max_size": "1GiB"},
+			},
+			"time_based": []interface{}{},
+		},
+	}, rotationStrategies)
+	require.Nil(t, err)
+	require.Equal(t, "org.graylog2.indexer.rotation.strategies.SizeBasedRotationStrategy", class)
+	require.Equal(t, map[string]interface{}{
+		"type":     "org.graylog2.indexer.rotation.strategies.SizeBasedRotationStrategyConfig",
+		"max_size": int64(1073741824),
+	}, cfg)
+
+	class, cfg, err = expandStrategy([]interface{}{
+		map[string]interface{}{
+			"time_based": []interface{}{
+				map[string]interface{}{"rotation_period": "P1D", "max_rotation_period": "", "rotate_empty_index_set": false},
+			},
+		},
+	}, rotationStrategies)
+	require.Nil(t, err)
+	require.Equal(t, "org.graylog2.indexer.rotation.strategies.TimeBasedRotationStrategy", class)
+	require.Equal(t, map[string]interface{}{
+		"type":                   "org.graylog2.indexer.rotation.strategies.TimeBasedRotationStrategyConfig",
+		"rotation_period":        "P1D",
+		"rotate_empty_index_set": false,
+	}, cfg)
+
+	class, cfg, err = expandStrategy([]interface{}{}, retentionStrategies)
+	require.Nil(t, err)
+	require.Equal(t, "", class)
+	require.Nil(t, cfg)
+
+	_, _, err = expandStrategy([]interface{}{map[string]interface{}{}}, retentionStrategies)
+	require.NotNil(t, err)
+}
+
+func TestFlattenStrategy(t *testing.T) {
+	sc := Resource().Schema
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"size_based": []interface{}{
+				map[string]interface{}{"max_size": "1073741824"},
+			},
+		},
+	}, flattenStrategy(
+		"org.graylog2.indexer.rotation.strategies.SizeBasedRotationStrategy",
+		map[string]interface{}{
+			"type":     "org.graylog2.indexer.rotation.strategies.SizeBasedRotationStrategyConfig",
+			"max_size": 1073741824.0,
+		}, rotationStrategies, sc[keyRotation]))
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"archive": []interface{}{
+				map[string]interface{}{"max_number_of_indices": 10.0, "index_action": "CLOSE"},
+			},
+		},
+	}, flattenStrategy(
+		"org.graylog.plugins.archive.indexer.retention.strategies.ArchiveRetentionStrategy",
+		map[string]interface{}{
+			"type":                  "org.graylog.plugins.archive.indexer.retention.strategies.ArchiveRetentionStrategyConfig",
+			"max_number_of_indices": 10.0,
+			"index_action":          "CLOSE",
+		}, retentionStrategies, sc[keyRetention]))
+
+	require.Nil(t, flattenStrategy("org.example.UnknownStrategy", nil, retentionStrategies, sc[keyRetention]))
+}
+
+func TestExpandDataTiering(t *testing.T) {
+	require.Equal(t, map[string]interface{}{
+		"type":               "hot_only",
+		"index_lifetime_min": "P30D",
+		"index_lifetime_max": "P40D",
+	}, expandDataTiering([]interface{}{}))
+
+	require.Equal(t, map[string]interface{}{
+		"type":                    "hot_warm",
+		"index_lifetime_min":      "P7D",
+		"index_lifetime_max":      "P30D",
+		"index_hot_lifetime_min":  "P3D",
+		"warm_tier_enabled":       true,
+		"archive_before_deletion": false,
+	}, expandDataTiering([]interface{}{
+		map[string]interface{}{
+			"type":                      "hot_warm",
+			"index_lifetime_min":        "P7D",
+			"index_lifetime_max":        "P30D",
+			"index_hot_lifetime_min":    "P3D",
+			"warm_tier_enabled":         true,
+			"warm_tier_repository_name": "",
+			"archive_before_deletion":   false,
+		},
+	}))
+}
+
+func TestUpgradeStateV1(t *testing.T) {
+	state, err := upgradeStateV1(map[string]interface{}{
+		"title":        "test",
+		"data_tiering": `{"type":"hot_only","index_lifetime_min":"P7D","index_lifetime_max":"P8D"}`,
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"title": "test",
+		"data_tiering": []interface{}{
+			map[string]interface{}{"type": "hot_only", "index_lifetime_min": "P7D", "index_lifetime_max": "P8D"},
+		},
+	}, state)
+
+	state, err = upgradeStateV1(map[string]interface{}{"title": "test"})
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{}, state["data_tiering"])
+}
+
+func TestGetDataFromResourceDataTypedBlocks(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Resource().Schema, map[string]interface{}{
+		"title":        "test",
+		"index_prefix": "test",
+		"rotation": []interface{}{
+			map[string]interface{}{
+				"message_count": []interface{}{
+					map[string]interface{}{"max_docs_per_index": 1000},
+				},
+			},
+		},
+		"retention": []interface{}{
+			map[string]interface{}{
+				"delete": []interface{}{
+					map[string]interface{}{},
+				},
+			},
+		},
+	})
+	data, err := getDataFromResourceData(d)
+	require.Nil(t, err)
+	require.Equal(t, "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategy", data["rotation_strategy_class"])
+	require.Equal(t, map[string]interface{}{
+		"type":               "org.graylog2.indexer.rotation.strategies.MessageCountRotationStrategyConfig",
+		"max_docs_per_index": 1000,
+	}, data["rotation_strategy"])
+	require.Equal(t, "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategy", data["retention_strategy_class"])
+	require.Equal(t, map[string]interface{}{
+		"type":                  "org.graylog2.indexer.retention.strategies.DeletionRetentionStrategyConfig",
+		"max_number_of_indices": 20,
+	}, data["retention_strategy"])
+	require.NotContains(t, data, "rotation")
+	require.NotContains(t, data, "retention")
+	require.Equal(t, "hot_only", data["data_tiering"].(map[string]interface{})["type"])
+}
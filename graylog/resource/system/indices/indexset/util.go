@@ -1,15 +1,23 @@
 package indexset
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
 )
 
 const (
-	keyID                = "id"
-	keyRotationStrategy  = "rotation_strategy"
-	keyRetentionStrategy = "retention_strategy"
-	keyDefault           = "default"
+	keyID                     = "id"
+	keyRotationStrategy       = "rotation_strategy"
+	keyRotationStrategyClass  = "rotation_strategy_class"
+	keyRetentionStrategy      = "retention_strategy"
+	keyRetentionStrategyClass = "retention_strategy_class"
+	keyRotation               = "rotation"
+	keyRetention              = "retention"
+	keyDataTiering            = "data_tiering"
+	keyMaxSize                = "max_size"
+	keyDefault                = "default"
 )
 
 func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
@@ -33,32 +41,43 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 		}
 	}
 
-	// data_tiering: parse JSON string into map
-	if s, ok := data["data_tiering"].(string); ok && s != "" {
-		m, err := convert.StringJSONToData(s)
+	// rotation/retention: render the typed blocks to the strategy classes and configs
+	for _, t := range strategyBlocks {
+		class, cfg, err := expandStrategy(data[t.key], t.strategies)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s is invalid: %w", t.key, err)
+		}
+		delete(data, t.key)
+		if class != "" {
+			data[t.classKey] = class
+			data[t.configKey] = cfg
 		}
-		data["data_tiering"] = m
-	} else {
-		delete(data, "data_tiering")
 	}
 
+	data[keyDataTiering] = expandDataTiering(data[keyDataTiering])
+
+	// field_restriction: render the typed blocks to field_restrictions.
 	// field_restrictions: optional JSON string
-	if v, ok := data["field_restrictions"].(string); ok && v != "" {
+	restrictions := expandFieldRestrictions(data[keyFieldRestriction])
+	delete(data, keyFieldRestriction)
+	if len(restrictions) != 0 {
+		data[keyFieldRestrictions] = restrictions
+	} else if v, ok := data[keyFieldRestrictions].(string); ok && v != "" {
 		m, err := convert.StringJSONToData(v)
 		if err != nil {
 			return nil, err
 		}
-		data["field_restrictions"] = m
+		data[keyFieldRestrictions] = m
 	} else {
-		delete(data, "field_restrictions")
+		delete(data, keyFieldRestrictions)
 	}
 
 	// Remove computed/unsupported fields from request (id is added back only for Update)
 	delete(data, "can_be_default")
 	delete(data, "creation_date")
-	delete(data, "field_type_profile")
+	if v, ok := data["field_type_profile"].(string); ok && v == "" {
+		delete(data, "field_type_profile")
+	}
 	if v, ok := data["index_template_type"].(string); ok && v == "" {
 		data["index_template_type"] = "default"
 	}
@@ -73,7 +92,36 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 }
 
 func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
-	if err := convert.DataToJSON(data, keyRotationStrategy, keyRetentionStrategy, "data_tiering", "field_restrictions"); err != nil {
+	// rotation/retention: the typed blocks are set instead of the strategy classes and configs
+	// if they are used in the configuration.
+	for _, t := range strategyBlocks {
+		if list, ok := d.Get(t.key).([]interface{}); !ok || len(list) == 0 {
+			continue
+		}
+		class, _ := data[t.classKey].(string)
+		cfg, _ := data[t.configKey].(map[string]interface{})
+		if err := d.Set(t.key, flattenStrategy(class, cfg, t.strategies, Resource().Schema[t.key])); err != nil {
+			return err
+		}
+		delete(data, t.classKey)
+		delete(data, t.configKey)
+	}
+
+	if err := d.Set(keyDataTiering, flattenDataTiering(data[keyDataTiering])); err != nil {
+		return err
+	}
+	delete(data, keyDataTiering)
+
+	// field_restriction: the typed blocks are set instead of field_restrictions
+	// if they are used in the configuration.
+	if set, ok := d.Get(keyFieldRestriction).(*schema.Set); ok && set.Len() != 0 {
+		if err := d.Set(keyFieldRestriction, flattenFieldRestrictions(data[keyFieldRestrictions])); err != nil {
+			return err
+		}
+		delete(data, keyFieldRestrictions)
+	}
+
+	if err := convert.DataToJSON(data, keyRotationStrategy, keyRetentionStrategy, keyFieldRestrictions); err != nil {
 		return err
 	}
 
@@ -95,11 +143,11 @@ func setDataToResourceData(d *schema.ResourceData, data map[string]interface{})
 	}
 
 	// field_restrictions: normalize null to empty object to match schema default
-	if v, ok := data["field_restrictions"]; ok {
+	if v, ok := data[keyFieldRestrictions]; ok {
 		if v == nil {
-			data["field_restrictions"] = "{}"
+			data[keyFieldRestrictions] = "{}"
 		} else if s, isStr := v.(string); isStr && s == "null" {
-			data["field_restrictions"] = "{}"
+			data[keyFieldRestrictions] = "{}"
 		}
 	}
 
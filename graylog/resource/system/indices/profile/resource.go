@@ -0,0 +1,159 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/indices/fieldtype"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyCustomFieldMapping  = "custom_field_mapping"
+	keyCustomFieldMappings = "custom_field_mappings"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Create: create,
+		Read:   read,
+		Update: update,
+		Delete: destroy,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importer.StateFunc(importer.IndexFieldTypeProfile),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			keyCustomFieldMapping: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(fieldtype.Types, false),
+						},
+					},
+				},
+			},
+			"index_set_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
+	mappings := []interface{}{}
+	fields := map[string]struct{}{}
+	for _, a := range d.Get(keyCustomFieldMapping).(*schema.Set).List() {
+		m := a.(map[string]interface{})
+		field := m["field"].(string)
+		if _, ok := fields[field]; ok {
+			return nil, fmt.Errorf("the field %q is mapped multiple times in %s", field, keyCustomFieldMapping)
+		}
+		fields[field] = struct{}{}
+		mappings = append(mappings, map[string]interface{}{
+			"field": field,
+			"type":  m["type"],
+		})
+	}
+	return map[string]interface{}{
+		"name":                 d.Get("name"),
+		"description":          d.Get("description"),
+		keyCustomFieldMappings: mappings,
+	}, nil
+}
+
+func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	util.RenameKey(data, keyCustomFieldMappings, keyCustomFieldMapping)
+	if _, ok := data[keyCustomFieldMapping]; !ok {
+		data[keyCustomFieldMapping] = []interface{}{}
+	}
+	return convert.SetResourceData(d, Resource(), data)
+}
+
+func create(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	data, err := getDataFromResourceData(d)
+	if err != nil {
+		return err
+	}
+	body, _, err := cl.IndexFieldTypeProfile.Create(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to create an index field type profile: %w", err)
+	}
+	id, ok := body["id"].(string)
+	if !ok || id == "" {
+		return errors.New("the response of Graylog API is unexpected. id of the created index field type profile is empty")
+	}
+	return util.ReadAfterCreate(d, m, id, read)
+}
+
+func read(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	data, resp, err := cl.IndexFieldTypeProfile.Get(ctx, d.Id())
+	if err != nil {
+		return util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get an index field type profile %s: %w", d.Id(), err))
+	}
+	return setDataToResourceData(d, data)
+}
+
+func update(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	data, err := getDataFromResourceData(d)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.IndexFieldTypeProfile.Update(ctx, d.Id(), data); err != nil {
+		return fmt.Errorf("failed to update an index field type profile %s: %w", d.Id(), err)
+	}
+	return read(d, m)
+}
+
+func destroy(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	cl, err := client.New(m)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.IndexFieldTypeProfile.Delete(ctx, d.Id()); err != nil {
+		return fmt.Errorf("failed to delete an index field type profile %s: %w", d.Id(), err)
+	}
+	return nil
+}
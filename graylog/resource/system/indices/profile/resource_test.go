@@ -0,0 +1,54 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDataFromResourceData(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Resource().Schema, map[string]interface{}{
+		"name":        "web",
+		"description": "web servers",
+		"custom_field_mapping": []interface{}{
+			map[string]interface{}{"field": "http_status", "type": "long"},
+		},
+	})
+	data, err := getDataFromResourceData(d)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"name":        "web",
+		"description": "web servers",
+		"custom_field_mappings": []interface{}{
+			map[string]interface{}{"field": "http_status", "type": "long"},
+		},
+	}, data)
+
+	d = schema.TestResourceDataRaw(t, Resource().Schema, map[string]interface{}{
+		"name": "web",
+		"custom_field_mapping": []interface{}{
+			map[string]interface{}{"field": "http_status", "type": "long"},
+			map[string]interface{}{"field": "http_status", "type": "string"},
+		},
+	})
+	_, err = getDataFromResourceData(d)
+	require.NotNil(t, err)
+}
+
+func TestSetDataToResourceData(t *testing.T) {
+	d := Resource().Data(nil)
+	d.SetId("6500f2e5c1b1d5263a4e0a33")
+	require.Nil(t, setDataToResourceData(d, map[string]interface{}{
+		"id":   "6500f2e5c1b1d5263a4e0a33",
+		"name": "web",
+		"custom_field_mappings": []interface{}{
+			map[string]interface{}{"field": "http_status", "type": "long"},
+			map[string]interface{}{"field": "client_ip", "type": "ip"},
+		},
+		"index_set_ids": []interface{}{"5ea25a282ab79c00125200b9"},
+	}))
+	require.Equal(t, "web", d.Get("name"))
+	require.Equal(t, 2, d.Get("custom_field_mapping").(*schema.Set).Len())
+	require.Equal(t, []interface{}{"5ea25a282ab79c00125200b9"}, d.Get("index_set_ids").(*schema.Set).List())
+}
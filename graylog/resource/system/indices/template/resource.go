@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -17,6 +18,11 @@ func Resource() *schema.Resource {
 		Read:   read,
 		Update: update,
 		Delete: destroy,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importer.StateFunc(importer.IndexSetTemplate),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:     schema.TypeString,
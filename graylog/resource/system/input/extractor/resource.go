@@ -2,6 +2,7 @@ package extractor
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -16,7 +17,8 @@ func Resource() *schema.Resource {
 		StateUpgraders: stateUpgraders,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: util.GenStateFunc(keyInputID, keyExtractorID),
+			StateContext: importer.GenStateFunc(
+				importer.Parent(keyInputID, importer.Input), importer.Child(keyExtractorID, importer.Extractor)),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,140 @@
+package messageprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/clusterconfig"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyProcessorOrder     = "processor_order"
+	keyDisabledProcessors = "disabled_processors"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: customizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			// The current order of Graylog is kept if processor_order isn't set.
+			keyProcessorOrder: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			keyDisabledProcessors: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// customizeDiff validates the processor classes at plan time.
+func customizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(keyProcessorOrder) {
+		return nil
+	}
+	order := convert.InterfaceListToStringList(d.Get(keyProcessorOrder).([]interface{}))
+	processors := make(map[string]struct{}, len(order))
+	for _, p := range order {
+		if _, ok := processors[p]; ok {
+			return fmt.Errorf("%s is duplicated in %s", p, keyProcessorOrder)
+		}
+		processors[p] = struct{}{}
+	}
+	if len(order) == 0 || !d.NewValueKnown(keyDisabledProcessors) {
+		return nil
+	}
+	for _, p := range convert.InterfaceListToStringList(d.Get(keyDisabledProcessors).(*schema.Set).List()) {
+		if _, ok := processors[p]; !ok {
+			return fmt.Errorf("%s in %s isn't in %s", p, keyDisabledProcessors, keyProcessorOrder)
+		}
+	}
+	return nil
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := update(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(clusterconfig.ClassMessageProcessors)
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, resp, err := cl.ClusterConfig.Get(ctx, clusterconfig.ClassMessageProcessors)
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get the message processors configuration: %w", err)))
+	}
+	if err := d.Set(keyProcessorOrder, data[keyProcessorOrder]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(keyDisabledProcessors, data[keyDisabledProcessors]); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := update(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceRead(ctx, d, m)
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Graylog uses the default order and enables all processors.
+	if _, err := cl.ClusterConfig.Delete(ctx, clusterconfig.ClassMessageProcessors); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete the message processors configuration: %w", err))
+	}
+	return nil
+}
+
+func update(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return err
+	}
+	data, _, err := cl.ClusterConfig.Get(ctx, clusterconfig.ClassMessageProcessors)
+	if err != nil {
+		return fmt.Errorf("failed to get the message processors configuration: %w", err)
+	}
+	if order, ok := d.GetOk(keyProcessorOrder); ok {
+		data[keyProcessorOrder] = order.([]interface{})
+	}
+	if _, ok := data[keyProcessorOrder]; !ok {
+		data[keyProcessorOrder] = []interface{}{}
+	}
+	data[keyDisabledProcessors] = d.Get(keyDisabledProcessors).(*schema.Set).List()
+	if _, err := cl.ClusterConfig.Update(ctx, clusterconfig.ClassMessageProcessors, data); err != nil {
+		return fmt.Errorf("failed to update the message processors configuration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package messageprocessor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+const (
+	filterChain = "org.graylog2.messageprocessors.MessageFilterChainProcessor"
+	pipeline    = "org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter"
+	geoIP       = "org.graylog.plugins.map.geoip.processor.GeoIpProcessor"
+)
+
+func TestAccMessageProcessorsConfig(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	configBody := `{
+  "processor_order": [
+    "org.graylog2.messageprocessors.MessageFilterChainProcessor",
+    "org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter",
+    "org.graylog.plugins.map.geoip.processor.GeoIpProcessor"
+  ],
+  "disabled_processors": [
+    "org.graylog.plugins.map.geoip.processor.GeoIpProcessor"
+  ]
+}`
+
+	resourceURLPath := "/api/system/cluster_config/org.graylog2.messageprocessors.MessageProcessorsConfig"
+	resourceName := "graylog_message_processors_config.test"
+
+	getRoute := flute.Route{
+		Name: "get the message processors configuration",
+		Matcher: flute.Matcher{
+			Method: "GET",
+		},
+		Tester: flute.Tester{
+			Path:         resourceURLPath,
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(configBody)),
+				}, nil
+			},
+		},
+	}
+
+	updateRoute := func(exp map[string]interface{}) flute.Route {
+		return flute.Route{
+			Name: "update the message processors configuration",
+			Matcher: flute.Matcher{
+				Method: "PUT",
+			},
+			Tester: flute.Tester{
+				Path:         resourceURLPath,
+				PartOfHeader: testutil.Header(),
+				Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+					b, err := ioutil.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					body := map[string]interface{}{}
+					if err := json.Unmarshal(b, &body); err != nil {
+						t.Fatal(err)
+					}
+					require.Equal(t, exp, body)
+					configBody = string(b)
+				},
+			},
+			Response: flute.Response{
+				Base: http.Response{
+					StatusCode: 202,
+				},
+			},
+		}
+	}
+
+	deleteRoute := flute.Route{
+		Name: "delete the message processors configuration",
+		Matcher: flute.Matcher{
+			Method: "DELETE",
+		},
+		Tester: flute.Tester{
+			Path:         resourceURLPath,
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 202,
+			},
+		},
+	}
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			// The order of Graylog is kept and all processors are enabled.
+			testutil.SetHTTPClient(t, getRoute, updateRoute(map[string]interface{}{
+				"processor_order":     []interface{}{filterChain, pipeline, geoIP},
+				"disabled_processors": []interface{}{},
+			}), deleteRoute)
+		},
+		Config: `
+resource "graylog_message_processors_config" "test" {}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", "org.graylog2.messageprocessors.MessageProcessorsConfig"),
+			resource.TestCheckResourceAttr(resourceName, "processor_order.#", "3"),
+			resource.TestCheckResourceAttr(resourceName, "processor_order.0", filterChain),
+			resource.TestCheckResourceAttr(resourceName, "disabled_processors.#", "0"),
+		),
+	}
+
+	updateStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, updateRoute(map[string]interface{}{
+				"processor_order":     []interface{}{pipeline, filterChain, geoIP},
+				"disabled_processors": []interface{}{geoIP},
+			}), deleteRoute)
+		},
+		Config: `
+resource "graylog_message_processors_config" "test" {
+  processor_order = [
+    "org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter",
+    "org.graylog2.messageprocessors.MessageFilterChainProcessor",
+    "org.graylog.plugins.map.geoip.processor.GeoIpProcessor",
+  ]
+  disabled_processors = [
+    "org.graylog.plugins.map.geoip.processor.GeoIpProcessor",
+  ]
+}
+`,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "processor_order.0", pipeline),
+			resource.TestCheckResourceAttr(resourceName, "processor_order.1", filterChain),
+			resource.TestCheckResourceAttr(resourceName, "disabled_processors.#", "1"),
+		),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_message_processors_config", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			updateStep,
+		},
+	})
+}
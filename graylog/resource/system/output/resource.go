@@ -2,6 +2,7 @@ package output
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
@@ -13,7 +14,7 @@ func Resource() *schema.Resource {
 		Delete: destroy,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.Output),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -21,16 +22,23 @@ func Resource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+			keyType: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: typedBlocks,
 			},
-			"configuration": {
+			keyConfiguration: {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				RequiredWith:     []string{keyType},
+				ExactlyOneOf:     typedBlockNames(),
 				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
+			keyGELF:       schemaGELF(),
+			keySTDOUT:     schemaSTDOUT(),
+			keyEnterprise: schemaEnterprise(),
 			"created_at": {
 				Type:     schema.TypeString,
 				Computed: true,
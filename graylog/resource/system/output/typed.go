@@ -0,0 +1,254 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyType                   = "type"
+	keyGELF                   = "gelf"
+	keySTDOUT                 = "stdout"
+	keyEnterprise             = "enterprise"
+	keySensitiveConfiguration = "sensitive_configuration"
+)
+
+// outputClasses maps the typed blocks of the built-in outputs to the output types.
+var outputClasses = map[string]string{
+	keyGELF:   "org.graylog2.outputs.GelfOutput",
+	keySTDOUT: "org.graylog2.outputs.LoggingOutput",
+}
+
+// builtinBlocks are the typed blocks of the built-in outputs.
+var builtinBlocks = []string{keyGELF, keySTDOUT}
+
+// typedBlocks are the blocks which can be used instead of type and configuration.
+var typedBlocks = append([]string{keyEnterprise}, builtinBlocks...)
+
+func schemaGELF() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: typedBlockNames(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"hostname": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"port": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      12201,
+					ValidateFunc: validation.IsPortNumber,
+				},
+				"protocol": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "TCP",
+					ValidateFunc: validation.StringInSlice([]string{"TCP", "UDP"}, false),
+				},
+				"connect_timeout": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1000,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"reconnect_delay": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      500,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"tcp_no_delay": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"tcp_keep_alive": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"queue_size": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      512,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"max_inflight_sends": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      512,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"tls_verification_enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"tls_trust_cert_chain": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func schemaSTDOUT() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: typedBlockNames(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"prefix": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "Writing message: ",
+				},
+			},
+		},
+	}
+}
+
+// schemaEnterprise is the block of the outputs of Graylog Enterprise such as the forwarding outputs.
+// Their configurations depend on the installed plugins, so only the secrets are separated from the configuration.
+func schemaEnterprise() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: typedBlockNames(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				keyType: {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				keyConfiguration: {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "{}",
+					DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+					ValidateFunc:     util.ValidateIsJSON,
+				},
+				keySensitiveConfiguration: {
+					Type:      schema.TypeMap,
+					Optional:  true,
+					Sensitive: true,
+					Elem:      &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// getBlock returns the first element of the single block list.
+func getBlock(v interface{}) map[string]interface{} {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil
+	}
+	m, _ := list[0].(map[string]interface{})
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
+
+// expandTypedBlock renders the typed block to the type and configuration of the output.
+// An empty type is returned if no typed block is set.
+func expandTypedBlock(data map[string]interface{}) (string, map[string]interface{}, error) {
+	if block := getBlock(data[keyEnterprise]); block != nil {
+		cfg, err := convert.StringJSONToData(block[keyConfiguration].(string))
+		if err != nil {
+			return "", nil, fmt.Errorf("configuration of %s is invalid: %w", keyEnterprise, err)
+		}
+		secrets, _ := block[keySensitiveConfiguration].(map[string]interface{})
+		for k, v := range secrets {
+			if _, ok := cfg[k]; ok {
+				return "", nil, fmt.Errorf("the key %s is set in both configuration and %s of %s", k, keySensitiveConfiguration, keyEnterprise)
+			}
+			cfg[k] = v
+		}
+		return block[keyType].(string), cfg, nil
+	}
+	for _, name := range builtinBlocks {
+		block := getBlock(data[name])
+		if block == nil {
+			continue
+		}
+		// Empty optional text fields such as tls_trust_cert_chain are omitted.
+		// An empty prefix of stdout is valid.
+		cfg := make(map[string]interface{}, len(block))
+		for k, v := range block {
+			if s, ok := v.(string); ok && s == "" && k != "prefix" {
+				continue
+			}
+			cfg[k] = v
+		}
+		return outputClasses[name], cfg, nil
+	}
+	return "", nil, nil
+}
+
+// flattenTypedBlock converts the configuration of the output to the typed block which is used in the configuration.
+// The secrets of the enterprise block aren't read from Graylog, which masks them.
+func flattenTypedBlock(d *schema.ResourceData, outputType string, cfg map[string]interface{}) (string, []interface{}, error) {
+	for _, name := range typedBlocks {
+		if list, ok := d.Get(name).([]interface{}); !ok || len(list) == 0 {
+			continue
+		}
+		if name == keyEnterprise {
+			block := getBlock(d.Get(name))
+			secrets, _ := block[keySensitiveConfiguration].(map[string]interface{})
+			rest := make(map[string]interface{}, len(cfg))
+			for k, v := range cfg {
+				if _, ok := secrets[k]; !ok {
+					rest[k] = v
+				}
+			}
+			b, err := json.Marshal(rest)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to marshal configuration of %s as JSON: %w", keyEnterprise, err)
+			}
+			return name, []interface{}{map[string]interface{}{
+				keyType:                   outputType,
+				keyConfiguration:          string(b),
+				keySensitiveConfiguration: secrets,
+			}}, nil
+		}
+		if outputType != outputClasses[name] {
+			// The type was changed outside Terraform. The block is removed to show the diff.
+			return name, []interface{}{}, nil
+		}
+		attrs := Resource().Schema[name].Elem.(*schema.Resource).Schema
+		block := make(map[string]interface{}, len(attrs))
+		for k, sc := range attrs {
+			v, ok := cfg[k]
+			if !ok || v == nil {
+				continue
+			}
+			if f, ok := v.(float64); ok && sc.Type == schema.TypeInt {
+				v = int(f)
+			}
+			block[k] = v
+		}
+		return name, []interface{}{block}, nil
+	}
+	return "", nil, nil
+}
+
+// typedBlockNames returns the sorted names of the typed blocks and configuration.
+func typedBlockNames() []string {
+	names := append([]string{keyConfiguration}, typedBlocks...)
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,250 @@
+package output
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+// outputRoutes returns the routes of the output API which store the created output in body.
+// The values of the keys masked are masked like Graylog masks the encrypted values.
+func outputRoutes(body *map[string]interface{}, masked ...string) []flute.Route {
+	resourceURLPath := "/api/system/outputs/5ea2a4442ab79c001274d9dc"
+	return []flute.Route{
+		{
+			Name: "get a output",
+			Matcher: flute.Matcher{
+				Method: "GET",
+				Path:   resourceURLPath,
+			},
+			Tester: flute.Tester{
+				PartOfHeader: testutil.Header(),
+			},
+			Response: flute.Response{
+				Response: func(req *http.Request) (*http.Response, error) {
+					output := map[string]interface{}{}
+					for k, v := range *body {
+						output[k] = v
+					}
+					cfg := map[string]interface{}{}
+					for k, v := range (*body)["configuration"].(map[string]interface{}) {
+						cfg[k] = v
+					}
+					for _, k := range masked {
+						cfg[k] = "<masked>"
+					}
+					output["configuration"] = cfg
+					b, err := json.Marshal(output)
+					if err != nil {
+						return nil, err
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(string(b))),
+					}, nil
+				},
+			},
+		},
+		{
+			Name: "create a output",
+			Matcher: flute.Matcher{
+				Method: "POST",
+				Path:   "/api/system/outputs",
+			},
+			Tester: flute.Tester{
+				PartOfHeader: testutil.Header(),
+				Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+					if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+						t.Fatal(err)
+					}
+					(*body)["id"] = "5ea2a4442ab79c001274d9dc"
+				},
+			},
+			Response: flute.Response{
+				Base: http.Response{
+					StatusCode: 201,
+				},
+				BodyString: `{
+  "id": "5ea2a4442ab79c001274d9dc"
+}`,
+			},
+		},
+		{
+			Name: "delete a output",
+			Matcher: flute.Matcher{
+				Method: "DELETE",
+				Path:   resourceURLPath,
+			},
+			Tester: flute.Tester{
+				PartOfHeader: testutil.Header(),
+			},
+			Response: flute.Response{
+				Base: http.Response{
+					StatusCode: 204,
+				},
+			},
+		},
+	}
+}
+
+func TestAccOutputGELF(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	body := map[string]interface{}{}
+	resourceName := "graylog_output.gelf"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_output", Resource()),
+		Steps: []resource.TestStep{
+			{
+				ResourceName: resourceName,
+				PreConfig: func() {
+					testutil.SetHTTPClient(t, outputRoutes(&body)...)
+				},
+				Config: `
+resource "graylog_output" "gelf" {
+  title = "gelf"
+
+  gelf {
+    hostname = "graylog.example.com"
+    protocol = "UDP"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					func(*terraform.State) error {
+						// the defaults of the omitted fields are sent
+						require.Equal(t, "org.graylog2.outputs.GelfOutput", body["type"])
+						require.Equal(t, map[string]interface{}{
+							"hostname":                 "graylog.example.com",
+							"port":                     float64(12201),
+							"protocol":                 "UDP",
+							"connect_timeout":          float64(1000),
+							"reconnect_delay":          float64(500),
+							"tcp_no_delay":             false,
+							"tcp_keep_alive":           false,
+							"queue_size":               float64(512),
+							"max_inflight_sends":       float64(512),
+							"tls_verification_enabled": false,
+						}, body["configuration"])
+						return nil
+					},
+					resource.TestCheckResourceAttr(resourceName, "type", "org.graylog2.outputs.GelfOutput"),
+					resource.TestCheckResourceAttr(resourceName, "gelf.0.port", "12201"),
+					resource.TestCheckResourceAttr(resourceName, "gelf.0.protocol", "UDP"),
+					resource.TestCheckResourceAttr(resourceName, "configuration", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOutputEnterprise(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	body := map[string]interface{}{}
+	resourceName := "graylog_output.forwarder"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_output", Resource()),
+		Steps: []resource.TestStep{
+			{
+				ResourceName: resourceName,
+				PreConfig: func() {
+					testutil.SetHTTPClient(t, outputRoutes(&body, "tls_key_password")...)
+				},
+				Config: `
+resource "graylog_output" "forwarder" {
+  title = "forwarder"
+
+  enterprise {
+    type = "org.graylog.enterprise.outputs.tcp.TcpOutput"
+    configuration = jsonencode({
+      hostname = "siem.example.com"
+      port     = 6514
+    })
+    sensitive_configuration = {
+      tls_key_password = "secret"
+    }
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					func(*terraform.State) error {
+						require.Equal(t, "org.graylog.enterprise.outputs.tcp.TcpOutput", body["type"])
+						require.Equal(t, "secret", body["configuration"].(map[string]interface{})["tls_key_password"])
+						return nil
+					},
+					// the masked secret isn't written to the state
+					resource.TestCheckResourceAttr(resourceName, "enterprise.0.configuration", `{"hostname":"siem.example.com","port":6514}`),
+					resource.TestCheckResourceAttr(resourceName, "enterprise.0.sensitive_configuration.tls_key_password", "secret"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandTypedBlock(t *testing.T) {
+	_, _, err := expandTypedBlock(map[string]interface{}{
+		"enterprise": []interface{}{
+			map[string]interface{}{
+				"type":                    "org.graylog.enterprise.outputs.tcp.TcpOutput",
+				"configuration":           `{"tls_key_password": "secret"}`,
+				"sensitive_configuration": map[string]interface{}{"tls_key_password": "secret"},
+			},
+		},
+	})
+	require.NotNil(t, err)
+
+	outputType, c, err := expandTypedBlock(map[string]interface{}{
+		"stdout": []interface{}{map[string]interface{}{"prefix": ""}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "org.graylog2.outputs.LoggingOutput", outputType)
+	require.Equal(t, map[string]interface{}{"prefix": ""}, c)
+}
+
+func TestValidateTypedBlocks(t *testing.T) {
+	data := []struct {
+		title string
+		cfg   map[string]interface{}
+		isErr bool
+	}{
+		{"gelf", map[string]interface{}{
+			"title": "gelf", "gelf": []interface{}{map[string]interface{}{"hostname": "graylog.example.com"}},
+		}, false},
+		{"configuration", map[string]interface{}{
+			"title": "stdout", "type": "org.graylog2.outputs.LoggingOutput", "configuration": `{"prefix": "msg: "}`,
+		}, false},
+		{"configuration without type", map[string]interface{}{
+			"title": "stdout", "configuration": `{"prefix": "msg: "}`,
+		}, true},
+		{"no configuration", map[string]interface{}{"title": "stdout"}, true},
+		{"gelf and configuration", map[string]interface{}{
+			"title": "gelf", "type": "org.graylog2.outputs.GelfOutput", "configuration": `{}`,
+			"gelf": []interface{}{map[string]interface{}{"hostname": "graylog.example.com"}},
+		}, true},
+		{"invalid protocol", map[string]interface{}{
+			"title": "gelf", "gelf": []interface{}{map[string]interface{}{"hostname": "graylog.example.com", "protocol": "HTTP"}},
+		}, true},
+	}
+	for _, d := range data {
+		d := d
+		t.Run(d.title, func(t *testing.T) {
+			diags := Resource().Validate(terraform.NewResourceConfigRaw(d.cfg))
+			require.Equal(t, d.isErr, diags.HasError(), diags)
+		})
+	}
+}
@@ -15,7 +15,18 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 	if err != nil {
 		return nil, err
 	}
-	if err := convert.JSONToData(data, keyConfiguration); err != nil {
+	// gelf, stdout and enterprise: render the typed block to the type and configuration
+	outputType, cfg, err := expandTypedBlock(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range typedBlocks {
+		delete(data, name)
+	}
+	if outputType != "" {
+		data[keyType] = outputType
+		data[keyConfiguration] = cfg
+	} else if err := convert.JSONToData(data, keyConfiguration); err != nil {
 		return nil, err
 	}
 
@@ -28,7 +39,19 @@ func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, er
 }
 
 func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
-	if err := convert.DataToJSON(data, keyConfiguration); err != nil {
+	// The typed block is set instead of configuration if it's used in the configuration.
+	outputType, _ := data[keyType].(string)
+	cfg, _ := data[keyConfiguration].(map[string]interface{})
+	name, block, err := flattenTypedBlock(d, outputType, cfg)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		if err := d.Set(name, block); err != nil {
+			return err
+		}
+		delete(data, keyConfiguration)
+	} else if err := convert.DataToJSON(data, keyConfiguration); err != nil {
 		return err
 	}
 
@@ -0,0 +1,211 @@
+package urlallowlist
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	clientPkg "github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/client/system/clusterconfig"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
+)
+
+const (
+	keyEntry    = "entry"
+	keyEntries  = "entries"
+	keyDisabled = "disabled"
+
+	entryTypeLiteral = "literal"
+	entryTypeRegex   = "regex"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreate,
+		ReadContext:   resourceRead,
+		UpdateContext: resourceUpdate,
+		DeleteContext: resourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: customizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			keyDisabled: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			keyEntry: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      entryTypeLiteral,
+							ValidateFunc: validation.StringInSlice([]string{entryTypeLiteral, entryTypeRegex}, false),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// customizeDiff checks the regular expressions of the entries at plan time.
+// Graylog uses Java regular expressions, so only the syntax which Go and Java have in common is checked.
+func customizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	entries, _ := d.Get(keyEntry).([]interface{})
+	for i, a := range entries {
+		entry, _ := a.(map[string]interface{})
+		if entry["type"] != entryTypeRegex || !d.NewValueKnown(fmt.Sprintf("%s.%d.value", keyEntry, i)) {
+			continue
+		}
+		value, _ := entry["value"].(string)
+		if _, err := regexp.Compile(value); err != nil {
+			return fmt.Errorf("%s.%d.value is an invalid regular expression: %w", keyEntry, i, err)
+		}
+	}
+	return nil
+}
+
+func resourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := update(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(clusterconfig.ClassURLAllowlist)
+	return resourceRead(ctx, d, m)
+}
+
+func resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data, resp, err := cl.ClusterConfig.Get(ctx, clusterconfig.ClassURLAllowlist)
+	if err != nil {
+		return diag.FromErr(util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get the URL allowlist: %w", err)))
+	}
+	entries, err := getEntries(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	list := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		list[i] = map[string]interface{}{
+			"title": entry["title"],
+			"type":  entry["type"],
+			"value": entry["value"],
+		}
+	}
+	if err := d.Set(keyEntry, list); err != nil {
+		return diag.FromErr(err)
+	}
+	disabled, _ := data[keyDisabled].(bool)
+	if err := d.Set(keyDisabled, disabled); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := update(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceRead(ctx, d, m)
+}
+
+func resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Graylog uses the default allowlist, which is empty and enabled.
+	if _, err := cl.ClusterConfig.Delete(ctx, clusterconfig.ClassURLAllowlist); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete the URL allowlist: %w", err))
+	}
+	return nil
+}
+
+func update(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	cl, err := clientPkg.New(m)
+	if err != nil {
+		return err
+	}
+	data, _, err := cl.ClusterConfig.Get(ctx, clusterconfig.ClassURLAllowlist)
+	if err != nil {
+		return fmt.Errorf("failed to get the URL allowlist: %w", err)
+	}
+	current, err := getEntries(data)
+	if err != nil {
+		return err
+	}
+	data = map[string]interface{}{
+		keyEntries:  expandEntries(d.Get(keyEntry).([]interface{}), current),
+		keyDisabled: d.Get(keyDisabled).(bool),
+	}
+	if _, err := cl.ClusterConfig.Update(ctx, clusterconfig.ClassURLAllowlist, data); err != nil {
+		return fmt.Errorf("failed to update the URL allowlist: %w", err)
+	}
+	return nil
+}
+
+// getEntries returns the entries of the allowlist.
+// Graylog returns no content if the allowlist isn't stored, e.g. after the resource is deleted.
+// Then the default allowlist is used, which is empty and enabled.
+func getEntries(data map[string]interface{}) ([]map[string]interface{}, error) {
+	if _, ok := data[keyEntries]; !ok {
+		return nil, nil
+	}
+	return util.GetList(data, keyEntries)
+}
+
+// expandEntries converts the entry blocks to the entries of Graylog API.
+// Graylog requires an unique ID per entry. The IDs of the current entries with the same type and value are kept,
+// and new IDs are generated for the other entries.
+func expandEntries(entries []interface{}, current []map[string]interface{}) []interface{} {
+	ids := make(map[string]string, len(current))
+	for _, entry := range current {
+		id, _ := entry["id"].(string)
+		ids[entryKey(entry)] = id
+	}
+	ret := make([]interface{}, len(entries))
+	for i, a := range entries {
+		entry := a.(map[string]interface{})
+		key := entryKey(entry)
+		id, ok := ids[key]
+		if !ok || id == "" {
+			id = uuid.New().String()
+		}
+		// an ID is used only once even if the entries are duplicated
+		delete(ids, key)
+		ret[i] = map[string]interface{}{
+			"id":    id,
+			"title": entry["title"],
+			"type":  entry["type"],
+			"value": entry["value"],
+		}
+	}
+	return ret
+}
+
+func entryKey(entry map[string]interface{}) string {
+	return fmt.Sprintf("%v\x00%v", entry["type"], entry["value"])
+}
@@ -0,0 +1,195 @@
+package urlallowlist
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/flute/v2/flute"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/testutil"
+)
+
+func TestExpandEntries(t *testing.T) {
+	entries := expandEntries([]interface{}{
+		map[string]interface{}{"title": "Slack", "type": "literal", "value": "https://hooks.slack.com/services/T000"},
+		map[string]interface{}{"title": "Internal", "type": "regex", "value": `^https://.*\.example\.com/.*$`},
+		map[string]interface{}{"title": "Slack again", "type": "literal", "value": "https://hooks.slack.com/services/T000"},
+	}, []map[string]interface{}{
+		{"id": "a5d44d30-ab2b-4a2d-9e7f-6e2cbbd0c6a7", "title": "Old title", "type": "literal", "value": "https://hooks.slack.com/services/T000"},
+		{"id": "0c0e4b6e-0b3a-4c7e-8a4e-0f5c38a6e0f2", "title": "Removed", "type": "literal", "value": "https://removed.example.com"},
+	})
+	require.Len(t, entries, 3)
+	require.Equal(t, map[string]interface{}{
+		"id": "a5d44d30-ab2b-4a2d-9e7f-6e2cbbd0c6a7", "title": "Slack", "type": "literal", "value": "https://hooks.slack.com/services/T000",
+	}, entries[0])
+
+	second := entries[1].(map[string]interface{})
+	third := entries[2].(map[string]interface{})
+	require.NotEmpty(t, second["id"])
+	require.NotEmpty(t, third["id"])
+	require.NotEqual(t, "a5d44d30-ab2b-4a2d-9e7f-6e2cbbd0c6a7", third["id"])
+	require.NotEqual(t, second["id"], third["id"])
+}
+
+func TestAccURLAllowlist(t *testing.T) {
+	if err := testutil.SetEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Graylog returns no content if the allowlist isn't stored
+	configBody := ""
+
+	resourceURLPath := "/api/system/cluster_config/org.graylog2.system.urlallowlist.UrlAllowlist"
+	resourceName := "graylog_url_allowlist.test"
+
+	getRoute := flute.Route{
+		Name: "get the URL allowlist",
+		Matcher: flute.Matcher{
+			Method: "GET",
+		},
+		Tester: flute.Tester{
+			Path:         resourceURLPath,
+			PartOfHeader: testutil.Header(),
+		},
+		Response: flute.Response{
+			Response: func(req *http.Request) (*http.Response, error) {
+				if configBody == "" {
+					return &http.Response{
+						StatusCode: 204,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(configBody)),
+				}, nil
+			},
+		},
+	}
+
+	updateRoute := flute.Route{
+		Name: "update the URL allowlist",
+		Matcher: flute.Matcher{
+			Method: "PUT",
+		},
+		Tester: flute.Tester{
+			Path:         resourceURLPath,
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				b, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body := map[string]interface{}{}
+				if err := json.Unmarshal(b, &body); err != nil {
+					t.Fatal(err)
+				}
+				require.Equal(t, false, body["disabled"])
+				entries := body["entries"].([]interface{})
+				require.Len(t, entries, 1)
+				entry := entries[0].(map[string]interface{})
+				require.NotEmpty(t, entry["id"])
+				require.Equal(t, "https://hooks.slack.com/services/T000", entry["value"])
+				configBody = string(b)
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 202,
+			},
+		},
+	}
+
+	deleteRoute := flute.Route{
+		Name: "delete the URL allowlist",
+		Matcher: flute.Matcher{
+			Method: "DELETE",
+		},
+		Tester: flute.Tester{
+			Path:         resourceURLPath,
+			PartOfHeader: testutil.Header(),
+			Test: func(t *testing.T, req *http.Request, svc flute.Service, route flute.Route) {
+				configBody = ""
+			},
+		},
+		Response: flute.Response{
+			Base: http.Response{
+				StatusCode: 202,
+			},
+		},
+	}
+
+	config := `
+resource "graylog_url_allowlist" "test" {
+  entry {
+    title = "Slack"
+    value = "https://hooks.slack.com/services/T000"
+  }
+}
+`
+
+	createStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, updateRoute, deleteRoute)
+		},
+		Config: config,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "id", "org.graylog2.system.urlallowlist.UrlAllowlist"),
+			resource.TestCheckResourceAttr(resourceName, "disabled", "false"),
+			resource.TestCheckResourceAttr(resourceName, "entry.#", "1"),
+			resource.TestCheckResourceAttr(resourceName, "entry.0.title", "Slack"),
+			resource.TestCheckResourceAttr(resourceName, "entry.0.type", "literal"),
+		),
+	}
+
+	destroyStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, updateRoute, deleteRoute)
+		},
+		Config:  config,
+		Destroy: true,
+	}
+
+	// the allowlist can be created again after it is deleted
+	recreateStep := resource.TestStep{
+		ResourceName: resourceName,
+		PreConfig: func() {
+			testutil.SetHTTPClient(t, getRoute, updateRoute, deleteRoute)
+		},
+		Config: config,
+		Check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "entry.#", "1"),
+		),
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testutil.SingleResourceProviders("graylog_url_allowlist", Resource()),
+		Steps: []resource.TestStep{
+			createStep,
+			destroyStep,
+			recreateStep,
+		},
+	})
+}
+
+func TestGetEntries(t *testing.T) {
+	// the allowlist isn't stored
+	entries, err := getEntries(map[string]interface{}{})
+	require.Nil(t, err)
+	require.Empty(t, entries)
+
+	entries, err = getEntries(map[string]interface{}{
+		"entries": []interface{}{
+			map[string]interface{}{"id": "a5d44d30-ab2b-4a2d-9e7f-6e2cbbd0c6a7", "title": "Slack", "type": "literal", "value": "https://hooks.slack.com/services/T000"},
+		},
+		"disabled": false,
+	})
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+}
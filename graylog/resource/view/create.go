@@ -2,9 +2,12 @@ package view
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
 func create(d *schema.ResourceData, m interface{}) error {
@@ -17,11 +20,48 @@ func create(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
+	search, err := getSearchFromResourceData(d)
+	if err != nil {
+		return err
+	}
+
+	searchID := d.Get(keySearchID).(string)
+	if search != nil {
+		searchID, err = createSearch(ctx, cl, search)
+		if err != nil {
+			return err
+		}
+	}
+	if searchID == "" {
+		return errors.New("one of search or search_id must be set")
+	}
+	data[keySearchID] = searchID
 
 	ds, _, err := cl.View.Create(ctx, data)
 	if err != nil {
-		return err
+		if search != nil {
+			// don't leave the search created for this view behind
+			_, _ = cl.ViewSearch.Delete(ctx, searchID)
+		}
+		return fmt.Errorf("failed to create a view: %w", err)
+	}
+	id, ok := ds[keyID].(string)
+	if !ok || id == "" {
+		return errors.New("response body of Graylog API is unexpected. 'id' isn't found")
+	}
+	d.SetId(id)
+	return util.ReadAfterCreate(d, m, id, read)
+}
+
+// createSearch creates a search document and returns its id.
+func createSearch(ctx context.Context, cl client.Client, search map[string]interface{}) (string, error) {
+	body, _, err := cl.ViewSearch.Create(ctx, search)
+	if err != nil {
+		return "", fmt.Errorf("failed to create a search for the view: %w", err)
+	}
+	id, ok := body[keyID].(string)
+	if !ok || id == "" {
+		return "", errors.New("search creation did not return an id")
 	}
-	d.SetId(ds["id"].(string))
-	return nil
+	return id, nil
 }
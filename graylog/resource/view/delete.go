@@ -2,6 +2,7 @@ package view
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
@@ -14,7 +15,7 @@ func destroy(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	if _, err := cl.View.Delete(ctx, d.Id()); err != nil {
-		return err
+		return fmt.Errorf("failed to delete a view %s: %w", d.Id(), err)
 	}
 	return nil
 }
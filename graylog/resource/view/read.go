@@ -2,6 +2,7 @@ package view
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
@@ -16,7 +17,16 @@ func read(d *schema.ResourceData, m interface{}) error {
 	}
 	data, resp, err := cl.View.Get(ctx, d.Id())
 	if err != nil {
-		return util.HandleGetResourceError(d, resp, err)
+		return util.HandleGetResourceError(
+			d, resp, fmt.Errorf("failed to get a view %s: %w", d.Id(), err))
 	}
-	return setDataToResourceData(d, data)
+
+	var search map[string]interface{}
+	if searchID, ok := data[keySearchID].(string); ok && searchID != "" {
+		search, _, err = cl.ViewSearch.Get(ctx, searchID)
+		if err != nil {
+			return fmt.Errorf("failed to get a search %s of the view %s: %w", searchID, d.Id(), err)
+		}
+	}
+	return setDataToResourceData(d, data, search)
 }
@@ -2,6 +2,9 @@ package view
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/importer"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
 func Resource() *schema.Resource {
@@ -11,35 +14,39 @@ func Resource() *schema.Resource {
 		Update: update,
 		Delete: destroy,
 
+		SchemaVersion:  schemaVersion,
+		StateUpgraders: stateUpgraders,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateFunc(importer.View),
 		},
 
 		Schema: map[string]*schema.Schema{
-			// create
-			// state, search_id, properties, summary, title, type, id, description, requires, owner, created_at
-
 			// Required
 			"title": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 
+			"state": schemaState,
+
+			// Optional
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SEARCH",
+				ValidateFunc: validation.StringInSlice([]string{"SEARCH", "DASHBOARD"}, false),
 			},
 
+			// Either search_id refers to an existing search or search is managed by the view
 			"search_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{keySearch, keySearchID},
 			},
 
-			"state": schemaState,
-
-			// Optional
-			// "properties": [],
-			// "requires": {},
+			"search": schemaSearch,
 
 			"summary": {
 				Type:     schema.TypeString,
@@ -51,6 +58,22 @@ func Resource() *schema.Resource {
 				Optional: true,
 			},
 
+			"properties": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "[]",
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				ValidateFunc:     util.ValidateIsJSON,
+			},
+
+			"requires": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				ValidateFunc:     util.ValidateIsMapJSON,
+			},
+
 			"owner": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -5,62 +5,70 @@ import (
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/util"
 )
 
-var schemaState = &schema.Schema{
-	Type:     schema.TypeList,
-	Required: true,
+// schemaSearch is the search document backing the view.
+// queries and parameters are JSON strings in the format of the /views/search API.
+var schemaSearch = &schema.Schema{
+	Type:         schema.TypeList,
+	Optional:     true,
+	Computed:     true,
+	MaxItems:     1,
+	ExactlyOneOf: []string{keySearch, keySearchID},
 	Elem: &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
-				Optional: true,
+				Computed: true,
 			},
-
-			// required
-			// widgets widgetMapping widgetPositions
-			"widgets": schemaWidgets,
-			"widget_mapping": {
+			"queries": {
 				Type:             schema.TypeString,
-				Optional:         true,
-				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				Required:         true,
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONSubset,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
-
-			"positions": {
+			"parameters": {
 				Type:             schema.TypeString,
 				Optional:         true,
+				Default:          "[]",
 				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
-
-			"titles": schemaTitles,
-
-			// "selected_fields": {
-			// 	Type:     schema.TypeList,
-			// 	Optional: true,
-			// 	Elem: &schema.Schema{
-			// 		Type: schema.TypeString,
-			// 	},
-			// },
-			// "static_message_list_id": null,
-			// "formatting": null,
-			// "display_mode_settings": {
-			//   "positions": {}
-			// }
 		},
 	},
 }
 
-var schemaTitles = &schema.Schema{
+// schemaState is the view state. Graylog stores it as a map keyed by query id,
+// the provider exposes it as a list of blocks whose id is the query id.
+var schemaState = &schema.Schema{
 	Type:     schema.TypeList,
-	Optional: true,
+	Required: true,
+	MinItems: 1,
 	Elem: &schema.Resource{
 		Schema: map[string]*schema.Schema{
-			"widget": {
-				Type:     schema.TypeMap,
+			"id": {
+				Type:     schema.TypeString,
 				Required: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+			},
+			"widgets": schemaWidgets,
+			"widget_mapping": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONSubset,
+				ValidateFunc:     util.ValidateIsJSON,
+			},
+			"positions": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				ValidateFunc:     util.ValidateIsJSON,
+			},
+			"titles": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "{}",
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				ValidateFunc:     util.ValidateIsJSON,
 			},
 		},
 	},
@@ -69,13 +77,13 @@ var schemaTitles = &schema.Schema{
 var schemaQuery = &schema.Schema{
 	Type:     schema.TypeList,
 	Optional: true,
-	MinItems: 1,
 	MaxItems: 1,
 	Elem: &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"type": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Default:  "elasticsearch",
 			},
 			"query_string": {
 				Type:     schema.TypeString,
@@ -87,14 +95,12 @@ var schemaQuery = &schema.Schema{
 
 var schemaWidgets = &schema.Schema{
 	Type:     schema.TypeList,
-	Required: true,
+	Optional: true,
 	Elem: &schema.Resource{
 		Schema: map[string]*schema.Schema{
-			// required
-			// id type config
 			"widget_id": {
 				Type:     schema.TypeString,
-				Optional: true,
+				Required: true,
 			},
 			"type": {
 				Type:     schema.TypeString,
@@ -103,18 +109,25 @@ var schemaWidgets = &schema.Schema{
 			"config": {
 				Type:             schema.TypeString,
 				Required:         true,
-				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
+				DiffSuppressFunc: util.SchemaDiffSuppressJSONSubset,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
-			// "filter": null,
 			"timerange": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				DiffSuppressFunc: util.SchemaDiffSuppressJSONString,
 				ValidateFunc:     util.ValidateIsJSON,
 			},
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"query": schemaQuery,
-			// "streams": [],
+			"streams": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	},
 }
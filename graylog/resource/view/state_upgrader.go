@@ -0,0 +1,63 @@
+package view
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const schemaVersion = 1
+
+var stateUpgraders = []schema.StateUpgrader{
+	stateUpgraderV1,
+}
+
+func viewResourceV0() *schema.Resource {
+	return &schema.Resource{}
+}
+
+// stateUpgraderV1 converts the state's titles block `titles { widget = {...} }`
+// to the JSON string `{"widget": {...}}` and fills the attributes added in v1.
+var stateUpgraderV1 = schema.StateUpgrader{
+	Version: 0,
+	Type:    viewResourceV0().CoreConfigSchema().ImpliedType(),
+	Upgrade: func(_ context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		return upgradeStateV0(rawState)
+	},
+}
+
+func upgradeStateV0(rawState map[string]interface{}) (map[string]interface{}, error) {
+	states, _ := rawState[keyState].([]interface{})
+	for _, a := range states {
+		state, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		titles := map[string]interface{}{}
+		if list, ok := state[keyTitles].([]interface{}); ok && len(list) > 0 {
+			if elem, ok := list[0].(map[string]interface{}); ok {
+				if w, ok := elem["widget"]; ok && w != nil {
+					titles["widget"] = w
+				}
+			}
+		}
+		b, err := json.Marshal(titles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attributes '%s' as JSON: %w", keyTitles, err)
+		}
+		state[keyTitles] = string(b)
+		for _, k := range []string{keyPositions, keyWidgetMapping} {
+			if v, ok := state[k].(string); !ok || v == "" {
+				state[k] = "{}"
+			}
+		}
+	}
+	for k, def := range map[string]string{keyProperties: "[]", keyRequires: "{}"} {
+		if v, ok := rawState[k].(string); !ok || v == "" {
+			rawState[k] = def
+		}
+	}
+	return rawState, nil
+}
@@ -2,6 +2,7 @@ package view
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/client"
@@ -19,11 +20,27 @@ func update(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	searchID := d.Get(keySearchID).(string)
+	if d.HasChange(keySearch) {
+		search, err := getSearchFromResourceData(d)
+		if err != nil {
+			return err
+		}
+		if search != nil {
+			// Graylog searches are immutable, so a changed search document is created as a new search
+			searchID, err = createSearch(ctx, cl, search)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	data[keySearchID] = searchID
+
 	// Remove computed fields for Graylog 7.0 compatibility
 	util.RemoveComputedFields(data)
 
 	if _, _, err := cl.View.Update(ctx, d.Id(), data); err != nil {
-		return err
+		return fmt.Errorf("failed to update a view %s: %w", d.Id(), err)
 	}
-	return nil
+	return read(d, m)
 }
@@ -1,6 +1,11 @@
 package view
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/convert"
 )
@@ -9,62 +14,375 @@ const (
 	keyID            = "id"
 	keyWidgetMapping = "widget_mapping"
 	keyPositions     = "positions"
+	keyTitles        = "titles"
 	keyState         = "state"
 	keyWidgets       = "widgets"
 	keyConfig        = "config"
 	keyTimerange     = "timerange"
 	keyWidgetID      = "widget_id"
+	keyQuery         = "query"
+	keyStreams       = "streams"
+	keyFilter        = "filter"
+	keySearch        = "search"
+	keySearchID      = "search_id"
+	keyQueries       = "queries"
+	keyParameters    = "parameters"
+	keyProperties    = "properties"
+	keyRequires      = "requires"
+	keyCreatedAt     = "created_at"
+	keyOwner         = "owner"
 )
 
+// getDataFromResourceData converts the resource data to a ViewDTO.
+// search_id isn't set here because it depends on whether the search is managed by the view.
 func getDataFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
-	data, err := convert.GetFromResourceData(d, Resource())
-	if err != nil {
-		return nil, err
+	data := map[string]interface{}{
+		"title":       d.Get("title").(string),
+		"type":        d.Get("type").(string),
+		"summary":     d.Get("summary").(string),
+		"description": d.Get("description").(string),
+	}
+	if owner, ok := d.GetOk(keyOwner); ok {
+		data[keyOwner] = owner
 	}
 
-	state := data[keyState].(map[string]interface{})
-
-	if err := convert.JSONToData(state, keyWidgetMapping, keyPositions); err != nil {
-		return nil, err
+	for _, k := range []string{keyProperties, keyRequires} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(d.Get(k).(string)), &v); err != nil {
+			return nil, fmt.Errorf("failed to parse the '%s'. '%s' must be a JSON string: %w", k, k, err)
+		}
+		data[k] = v
 	}
 
-	widgets := convert.ListToMap(state[keyWidgets].([]interface{}), keyWidgetID)
-	for k, a := range widgets {
-		widget := a.(map[string]interface{})
-		if err := convert.JSONToData(widget, keyConfig, keyTimerange); err != nil {
+	stateList := d.Get(keyState).([]interface{})
+	stateMap := make(map[string]interface{}, len(stateList))
+	for _, a := range stateList {
+		state, err := expandState(a.(map[string]interface{}))
+		if err != nil {
 			return nil, err
 		}
-		widgets[k] = widget
+		stateID := state[keyID].(string)
+		delete(state, keyID)
+		if _, ok := stateMap[stateID]; ok {
+			return nil, fmt.Errorf("state id '%s' is duplicated", stateID)
+		}
+		stateMap[stateID] = state
 	}
-	state[keyWidgets] = widgets
-	data[keyState] = state
+	data[keyState] = stateMap
 	return data, nil
 }
 
-func setDataToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
-	state := data[keyState].(map[string]interface{})
+// expandState converts a state block to a ViewStateDTO without mutating the resource data.
+func expandState(src map[string]interface{}) (map[string]interface{}, error) {
+	state := map[string]interface{}{
+		keyID: src[keyID],
+	}
+	for _, k := range []string{keyWidgetMapping, keyPositions, keyTitles} {
+		s, _ := src[k].(string)
+		if s == "" {
+			state[k] = map[string]interface{}{}
+			continue
+		}
+		m, err := convert.StringJSONToData(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the state's %s: %w", k, err)
+		}
+		state[k] = m
+	}
+
+	rawWidgets, _ := src[keyWidgets].([]interface{})
+	widgets := make([]interface{}, len(rawWidgets))
+	for i, a := range rawWidgets {
+		w := a.(map[string]interface{})
+		widget := map[string]interface{}{
+			keyID:  w[keyWidgetID],
+			"type": w["type"],
+		}
+		if s, _ := w[keyFilter].(string); s != "" {
+			widget[keyFilter] = s
+		}
+		for _, k := range []string{keyConfig, keyTimerange} {
+			s, _ := w[k].(string)
+			if s == "" {
+				continue
+			}
+			var v interface{}
+			if err := json.Unmarshal([]byte(s), &v); err != nil {
+				return nil, fmt.Errorf("failed to parse the widget's %s: %w", k, err)
+			}
+			widget[k] = v
+		}
+		if q, ok := w[keyQuery].([]interface{}); ok && len(q) > 0 && q[0] != nil {
+			widget[keyQuery] = q[0]
+		}
+		streams := []interface{}{}
+		if set, ok := w[keyStreams].(*schema.Set); ok {
+			streams = set.List()
+		}
+		widget[keyStreams] = streams
+		widgets[i] = widget
+	}
+	state[keyWidgets] = widgets
+	return state, nil
+}
+
+// getSearchFromResourceData converts the search block to a search document.
+// It returns nil if the search block isn't set.
+func getSearchFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
+	list := d.Get(keySearch).([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil, nil
+	}
+	elem := list[0].(map[string]interface{})
+
+	var queries []interface{}
+	if err := json.Unmarshal([]byte(elem[keyQueries].(string)), &queries); err != nil {
+		return nil, fmt.Errorf("search.queries must be a JSON array: %w", err)
+	}
+	queryIDs := make(map[string]struct{}, len(queries))
+	for _, a := range queries {
+		query, ok := a.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("each element of search.queries must be a JSON object")
+		}
+		id, _ := query[keyID].(string)
+		if id == "" {
+			return nil, errors.New("each element of search.queries must have an id")
+		}
+		queryIDs[id] = struct{}{}
+	}
+
+	// every state entry is keyed by a query id of the search
+	for _, a := range d.Get(keyState).([]interface{}) {
+		stateID, _ := a.(map[string]interface{})[keyID].(string)
+		if _, ok := queryIDs[stateID]; !ok {
+			return nil, fmt.Errorf("state id '%s' doesn't match any query id of search.queries", stateID)
+		}
+	}
+
+	var parameters []interface{}
+	if s, _ := elem[keyParameters].(string); s != "" {
+		if err := json.Unmarshal([]byte(s), &parameters); err != nil {
+			return nil, fmt.Errorf("search.parameters must be a JSON array: %w", err)
+		}
+	}
+	if parameters == nil {
+		parameters = []interface{}{}
+	}
+
+	return map[string]interface{}{
+		keyQueries:    queries,
+		keyParameters: parameters,
+	}, nil
+}
+
+// flattenSearch converts a search document returned by the API to the search block.
+func flattenSearch(search map[string]interface{}) ([]interface{}, error) {
+	queries, ok := search[keyQueries]
+	if !ok || queries == nil {
+		queries = []interface{}{}
+	}
+	q, err := json.Marshal(queries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search queries: %w", err)
+	}
+	parameters, ok := search[keyParameters]
+	if !ok || parameters == nil {
+		parameters = []interface{}{}
+	}
+	p, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search parameters: %w", err)
+	}
+	return []interface{}{
+		map[string]interface{}{
+			keyID:         search[keyID],
+			keyQueries:    string(q),
+			keyParameters: string(p),
+		},
+	}, nil
+}
 
-	if err := convert.DataToJSON(state, keyWidgetMapping, keyPositions); err != nil {
-		return err
+func setDataToResourceData(d *schema.ResourceData, data, search map[string]interface{}) error {
+	stateMap, ok := data[keyState].(map[string]interface{})
+	if !ok {
+		return errors.New("view state is missing")
 	}
 
-	widgets := convert.MapToList(state[keyWidgets].(map[string]interface{}), keyWidgetID)
-	for i, a := range widgets {
-		widget := a.(map[string]interface{})
-		if err := convert.DataToJSON(widget, keyConfig, keyTimerange); err != nil {
+	states := make([]interface{}, 0, len(stateMap))
+	for _, stateID := range getStateIDOrder(d, stateMap) {
+		state, err := flattenState(
+			stateID, stateMap[stateID].(map[string]interface{}), getWidgetIDOrder(d, stateID))
+		if err != nil {
 			return err
 		}
-		widgets[i] = widget
+		states = append(states, state)
+	}
+	if err := d.Set(keyState, states); err != nil {
+		return fmt.Errorf("failed to set state: %w", err)
 	}
 
-	state[keyWidgets] = widgets
+	for _, k := range []string{"title", "type", "summary", "description", keySearchID, keyOwner, keyCreatedAt} {
+		if v, ok := data[k]; ok {
+			if err := d.Set(k, v); err != nil {
+				return fmt.Errorf("failed to set %s: %w", k, err)
+			}
+		}
+	}
 
-	data[keyState] = state
+	for k, def := range map[string]interface{}{keyProperties: []interface{}{}, keyRequires: map[string]interface{}{}} {
+		v, ok := data[k]
+		if !ok || v == nil {
+			v = def
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", k, err)
+		}
+		if err := d.Set(k, string(b)); err != nil {
+			return fmt.Errorf("failed to set %s: %w", k, err)
+		}
+	}
 
-	if err := convert.SetResourceData(d, Resource(), data); err != nil {
-		return err
+	if search != nil {
+		s, err := flattenSearch(search)
+		if err != nil {
+			return err
+		}
+		if err := d.Set(keySearch, s); err != nil {
+			return fmt.Errorf("failed to set search: %w", err)
+		}
 	}
 
-	d.SetId(data[keyID].(string))
+	id, ok := data[keyID].(string)
+	if !ok {
+		return errors.New("failed to set id. 'id' isn't found")
+	}
+	d.SetId(id)
 	return nil
 }
+
+// getStateIDOrder keeps the order of the state blocks in the Terraform state and
+// appends unknown ids in alphabetical order.
+func getStateIDOrder(d *schema.ResourceData, stateMap map[string]interface{}) []string {
+	order := make([]string, 0, len(stateMap))
+	used := make(map[string]struct{}, len(stateMap))
+	if stored, ok := d.Get(keyState).([]interface{}); ok {
+		for _, a := range stored {
+			s, ok := a.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := s[keyID].(string)
+			if _, ok := stateMap[id]; !ok {
+				continue
+			}
+			if _, ok := used[id]; ok {
+				continue
+			}
+			order = append(order, id)
+			used[id] = struct{}{}
+		}
+	}
+	remaining := make([]string, 0, len(stateMap)-len(order))
+	for id := range stateMap {
+		if _, ok := used[id]; !ok {
+			remaining = append(remaining, id)
+		}
+	}
+	sort.Strings(remaining)
+	return append(order, remaining...)
+}
+
+// getWidgetIDOrder returns the position of each widget in the Terraform state
+// because Graylog doesn't keep the order of widgets.
+func getWidgetIDOrder(d *schema.ResourceData, stateID string) map[string]int {
+	order := map[string]int{}
+	stored, _ := d.Get(keyState).([]interface{})
+	for _, a := range stored {
+		s, ok := a.(map[string]interface{})
+		if !ok || s[keyID] != stateID {
+			continue
+		}
+		widgets, _ := s[keyWidgets].([]interface{})
+		for i, w := range widgets {
+			if widget, ok := w.(map[string]interface{}); ok {
+				if id, ok := widget[keyWidgetID].(string); ok {
+					order[id] = i
+				}
+			}
+		}
+	}
+	return order
+}
+
+// flattenState converts a ViewStateDTO to a state block.
+// Widgets are sorted by widgetOrder, unknown widgets are appended by widget id.
+func flattenState(
+	stateID string, state map[string]interface{}, widgetOrder map[string]int,
+) (map[string]interface{}, error) {
+	ret := map[string]interface{}{
+		keyID: stateID,
+	}
+	for _, k := range []string{keyWidgetMapping, keyPositions, keyTitles} {
+		v, ok := state[k]
+		if !ok || v == nil {
+			v = map[string]interface{}{}
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", k, err)
+		}
+		ret[k] = string(b)
+	}
+
+	rawWidgets, _ := state[keyWidgets].([]interface{})
+	widgets := make([]interface{}, 0, len(rawWidgets))
+	for _, a := range rawWidgets {
+		w, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		widget := map[string]interface{}{
+			keyWidgetID: w[keyID],
+			"type":      w["type"],
+		}
+		if s, ok := w[keyFilter].(string); ok {
+			widget[keyFilter] = s
+		}
+		for _, k := range []string{keyConfig, keyTimerange} {
+			v, ok := w[k]
+			if !ok || v == nil {
+				continue
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal widget %s: %w", k, err)
+			}
+			widget[k] = string(b)
+		}
+		if q, ok := w[keyQuery].(map[string]interface{}); ok {
+			widget[keyQuery] = []interface{}{q}
+		}
+		if s, ok := w[keyStreams].([]interface{}); ok {
+			widget[keyStreams] = s
+		}
+		widgets = append(widgets, widget)
+	}
+	sort.SliceStable(widgets, func(i, j int) bool {
+		a, _ := widgets[i].(map[string]interface{})[keyWidgetID].(string)
+		b, _ := widgets[j].(map[string]interface{})[keyWidgetID].(string)
+		ia, okA := widgetOrder[a]
+		ib, okB := widgetOrder[b]
+		switch {
+		case okA && okB:
+			return ia < ib
+		case okA != okB:
+			return okA
+		default:
+			return a < b
+		}
+	})
+	ret[keyWidgets] = widgets
+	return ret, nil
+}
@@ -0,0 +1,101 @@
+package view
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSetDataToResourceData(t *testing.T) {
+	const body = `{
+		"id": "view-id",
+		"type": "SEARCH",
+		"title": "test",
+		"search_id": "search-id",
+		"properties": [],
+		"requires": {},
+		"state": {
+			"query-1": {
+				"widget_mapping": {"w-2": ["st-2"]},
+				"positions": {},
+				"titles": {"widget": {"w-2": "Messages"}},
+				"widgets": [
+					{"id": "w-2", "type": "messages", "config": {"fields": ["source"]}, "streams": ["s1"]},
+					{"id": "w-1", "type": "aggregation", "config": {"series": []}, "query": {"type": "elasticsearch", "query_string": "a:b"}}
+				]
+			}
+		}
+	}`
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		t.Fatal(err)
+	}
+	search := map[string]interface{}{
+		"id":      "search-id",
+		"queries": []interface{}{map[string]interface{}{"id": "query-1"}},
+	}
+
+	d := schema.TestResourceDataRaw(t, Resource().Schema, map[string]interface{}{})
+	if err := setDataToResourceData(d, data, search); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "view-id" {
+		t.Fatalf("id = %s, wanted view-id", d.Id())
+	}
+	if v := d.Get("search.0.parameters").(string); v != "[]" {
+		t.Fatalf("search.0.parameters = %s, wanted []", v)
+	}
+	if v := d.Get("state.0.widgets.0.widget_id").(string); v != "w-1" {
+		t.Fatalf("widgets should be sorted by widget_id without stored order, got %s", v)
+	}
+	if v := d.Get("state.0.widgets.0.query.0.query_string").(string); v != "a:b" {
+		t.Fatalf("query_string = %s, wanted a:b", v)
+	}
+
+	got, err := getDataFromResourceData(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := got["state"].(map[string]interface{})["query-1"].(map[string]interface{})
+	widgets := state["widgets"].([]interface{})
+	if len(widgets) != 2 {
+		t.Fatalf("the number of widgets = %d, wanted 2", len(widgets))
+	}
+	if id := widgets[0].(map[string]interface{})["id"]; id != "w-1" {
+		t.Fatalf("widget id = %v, wanted w-1", id)
+	}
+	if _, ok := state["titles"].(map[string]interface{})["widget"]; !ok {
+		t.Fatal("titles should be round-tripped")
+	}
+}
+
+func TestUpgradeStateV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":    "view-id",
+		"title": "test",
+		"state": []interface{}{
+			map[string]interface{}{
+				"id":        "query-1",
+				"positions": "",
+				"titles": []interface{}{
+					map[string]interface{}{"widget": map[string]interface{}{"w-1": "Count"}},
+				},
+			},
+		},
+	}
+	got, err := upgradeStateV0(rawState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := got["state"].([]interface{})[0].(map[string]interface{})
+	if v := state["titles"]; v != `{"widget":{"w-1":"Count"}}` {
+		t.Fatalf("titles = %v", v)
+	}
+	if v := state["positions"]; v != "{}" {
+		t.Fatalf("positions = %v, wanted {}", v)
+	}
+	if v := got["requires"]; v != "{}" {
+		t.Fatalf("requires = %v, wanted {}", v)
+	}
+}
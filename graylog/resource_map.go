@@ -10,57 +10,77 @@ import (
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/role"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/saved_search"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/sidecar"
+	sidecaraction "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/sidecar/action"
+	sidecarassignment "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/sidecar/assignment"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/sidecar/collector"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/sidecar/configuration"
+	sidecarvariable "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/sidecar/variable"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/alarmcallback"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/alert/condition"
+	streamClone "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/clone"
 	streamOutput "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/output"
 	streamRule "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/stream/rule"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/clusterconfig"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/grok"
+	indexSetCycle "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/indices/cycle"
 	fieldType "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/indices/fieldtype"
+	fieldTypes "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/indices/fieldtypes"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/indices/indexset"
+	fieldTypeProfile "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/indices/profile"
 	indexTemplate "github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/indices/template"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/input"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/input/extractor"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/input/staticfield"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/ldap/setting"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/messageprocessor"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/output"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/pipeline/connection"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/pipeline/pipeline"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/pipeline/rule"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/system/urlallowlist"
 	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/user"
+	"github.com/sven-borkert/terraform-provider-graylog/graylog/resource/view"
 )
 
 var resourceMap = map[string]*schema.Resource{
-	"graylog_alarm_callback":             alarmcallback.Resource(),
-	"graylog_alert_condition":            condition.Resource(),
-	"graylog_dashboard":                  dashboard.Resource(),
-	"graylog_dashboard_widget":           widget.Resource(),
-	"graylog_dashboard_widget_positions": position.Resource(),
-	"graylog_event_definition":           definition.Resource(),
-	"graylog_event_notification":         notification.Resource(),
-	"graylog_extractor":                  extractor.Resource(),
-	"graylog_grok_pattern":               grok.Resource(),
-	"graylog_index_set":                  indexset.Resource(),
-	"graylog_index_set_field_type":       fieldType.Resource(),
-	"graylog_index_set_template":         indexTemplate.Resource(),
-	"graylog_input":                      input.Resource(),
-	"graylog_input_static_fields":        staticfield.Resource(),
-	"graylog_ldap_setting":               setting.Resource(),
-	"graylog_output":                     output.Resource(),
-	"graylog_pipeline":                   pipeline.Resource(),
-	"graylog_pipeline_connection":        connection.Resource(),
-	"graylog_pipeline_rule":              rule.Resource(),
-	"graylog_role":                       role.Resource(),
-	"graylog_saved_search":               saved_search.Resource(),
-	"graylog_sidecars":                   sidecar.Resource(),
-	"graylog_sidecar_collector":          collector.Resource(),
-	"graylog_sidecar_configuration":      configuration.Resource(),
-	"graylog_stream":                     stream.Resource(),
-	"graylog_stream_output":              streamOutput.Resource(),
-	"graylog_stream_rule":                streamRule.Resource(),
-	"graylog_user":                       user.Resource(),
-	// TODO support view
-	// "graylog_view":                       view.Resource(),
+	"graylog_alarm_callback":                 alarmcallback.Resource(),
+	"graylog_alert_condition":                condition.Resource(),
+	"graylog_cluster_config":                 clusterconfig.Resource(),
+	"graylog_dashboard":                      dashboard.Resource(),
+	"graylog_dashboard_widget":               widget.Resource(),
+	"graylog_dashboard_widget_positions":     position.Resource(),
+	"graylog_event_definition":               definition.Resource(),
+	"graylog_event_notification":             notification.Resource(),
+	"graylog_extractor":                      extractor.Resource(),
+	"graylog_grok_pattern":                   grok.Resource(),
+	"graylog_index_field_type_profile":       fieldTypeProfile.Resource(),
+	"graylog_index_set":                      indexset.Resource(),
+	"graylog_index_set_cycle":                indexSetCycle.Resource(),
+	"graylog_index_set_field_type":           fieldType.Resource(),
+	"graylog_index_set_field_types":          fieldTypes.Resource(),
+	"graylog_index_set_template":             indexTemplate.Resource(),
+	"graylog_input":                          input.Resource(),
+	"graylog_input_static_fields":            staticfield.Resource(),
+	"graylog_ldap_setting":                   setting.Resource(),
+	"graylog_message_processors_config":      messageprocessor.Resource(),
+	"graylog_output":                         output.Resource(),
+	"graylog_pipeline":                       pipeline.Resource(),
+	"graylog_pipeline_connection":            connection.Resource(),
+	"graylog_pipeline_rule":                  rule.Resource(),
+	"graylog_role":                           role.Resource(),
+	"graylog_saved_search":                   saved_search.Resource(),
+	"graylog_sidecars":                       sidecar.Resource(),
+	"graylog_sidecar_assignment":             sidecarassignment.Resource(),
+	"graylog_sidecar_collector":              collector.Resource(),
+	"graylog_sidecar_collector_action":       sidecaraction.Resource(),
+	"graylog_sidecar_configuration":          configuration.Resource(),
+	"graylog_sidecar_configuration_variable": sidecarvariable.Resource(),
+	"graylog_stream":                         stream.Resource(),
+	"graylog_stream_clone":                   streamClone.Resource(),
+	"graylog_stream_output":                  streamOutput.Resource(),
+	"graylog_stream_rule":                    streamRule.Resource(),
+	"graylog_url_allowlist":                  urlallowlist.Resource(),
+	"graylog_user":                           user.Resource(),
+	"graylog_view":                           view.Resource(),
 }
@@ -250,3 +250,50 @@ func ComputeSHA256(content string) string {
 	hash := sha256.Sum256([]byte(content))
 	return hex.EncodeToString(hash[:])
 }
+
+// GetList returns the list of objects of the first key found in the response body of a list API.
+func GetList(body map[string]interface{}, keys ...string) ([]map[string]interface{}, error) {
+	for _, key := range keys {
+		a, ok := body[key]
+		if !ok {
+			continue
+		}
+		list, ok := a.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the response of Graylog API is unexpected. %q should be an array: %v", key, a)
+		}
+		ret := make([]map[string]interface{}, 0, len(list))
+		for _, elem := range list {
+			m, ok := elem.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf(
+					"the response of Graylog API is unexpected. the element of %q should be an object: %v", key, elem)
+			}
+			ret = append(ret, m)
+		}
+		return ret, nil
+	}
+	return nil, fmt.Errorf("the response of Graylog API is unexpected. %s isn't found", strings.Join(keys, " or "))
+}
+
+// GetAllPages gets all pages of a paginated list API whose page size is perPage.
+func GetAllPages(
+	perPage int, get func(page int) (map[string]interface{}, error), keys ...string,
+) ([]map[string]interface{}, error) {
+	var all []map[string]interface{}
+	for page := 1; ; page++ {
+		body, err := get(page)
+		if err != nil {
+			return nil, err
+		}
+		list, err := GetList(body, keys...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, list...)
+		total, _ := body["total"].(float64)
+		if len(list) < perPage || len(all) >= int(total) {
+			return all, nil
+		}
+	}
+}